@@ -3,7 +3,7 @@ package auth
 import (
 	"github.com/gorilla/sessions"
 	"github.com/markbates/goth"
-	"github.com/markbates/goth/providers/google"
+	"go.uber.org/zap"
 
 	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
 )
@@ -34,20 +34,35 @@ func InitStore(key string) {
 	logger.Log.Debug("auth: InitStore: info: session store initialized; HttpOnly=true, Secure=false")
 }
 
-// SetUpgoth registers OAuth providers with goth and wires up the session store.
+// SetUpgoth registers every configured OAuth/OIDC provider with goth and
+// wires up the session store.
 // Notes (human-mistake style):
-//   - If you accidentally pass empty clientId or secret (I do that sometimes) we log an
-//     error and don't register the provider so you don't end up with a half-configured flow.
-//   - We avoid logging secrets — logging the clientId is fine for debugging but never the secret.
-//   - If callback URL is empty we log a warning because the provider may misbehave.
-func SetUpgoth(clientId, secretkey, callbackfunc string) {
-	goth.UseProviders(google.New(clientId, secretkey, callbackfunc, "email", "profile"))
-	logger.Log.Debug("auth: SetUpgoth: info: google provider registered (clientId provided, secret not logged)")
+//   - If a provider fails to build (bad kind, missing discovery URL) we log
+//     an error and skip just that one, so one bad config entry doesn't take
+//     down every provider.
+//   - We avoid logging secrets — logging the clientId is fine for debugging
+//     but never the secret.
+func SetUpgoth(providers []Provider) {
+	built := make([]goth.Provider, 0, len(providers))
+	for _, p := range providers {
+		gp, err := p.build()
+		if err != nil {
+			logger.Log.Error("auth: SetUpgoth: failed to build provider", zap.String("name", p.Name), zap.String("kind", p.Kind), zap.Error(err))
+			continue
+		}
 
-	// Ensure goth's store is wired up from our Store.
-	err := GothicStoreWrapper()
-	if err == ""{
-		logger.Log.Error("auth: GothicStoreWrapper: warning: auth.Store is nil; gothic.Store not set")
+		gp.SetName(p.Name)
+		built = append(built, gp)
+		logger.Log.Debug("auth: SetUpgoth: info: provider registered (clientId provided, secret not logged)", zap.String("name", p.Name), zap.String("kind", p.Kind))
+	}
+
+	if len(built) == 0 {
+		logger.Log.Warn("auth: SetUpgoth: warning: no OAuth providers registered")
 		return
 	}
+
+	goth.UseProviders(built...)
+
+	// Ensure goth's store is wired up from our Store.
+	GothicStoreWrapper()
 }
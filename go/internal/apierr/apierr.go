@@ -0,0 +1,79 @@
+package apierr
+
+import (
+	"errors"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Error is the canonical shape for every error a handler can push onto the
+// Gin context with c.Error. It carries enough to render a stable JSON body
+// and enough to log the real cause without leaking it to the client.
+type Error struct {
+	Status  int            `json:"-"`
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Cause   error          `json:"-"`
+	Fields  map[string]any `json:"fields,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return e.Message + ": " + e.Cause.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+func new_(status int, code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+func BadRequest(code, message string) *Error   { return new_(http.StatusBadRequest, code, message) }
+func Unauthorized(code, message string) *Error { return new_(http.StatusUnauthorized, code, message) }
+func Forbidden(code, message string) *Error    { return new_(http.StatusForbidden, code, message) }
+func NotFound(code, message string) *Error     { return new_(http.StatusNotFound, code, message) }
+func Conflict(code, message string) *Error     { return new_(http.StatusConflict, code, message) }
+func Internal(code, message string) *Error     { return new_(http.StatusInternalServerError, code, message) }
+
+// WithCause attaches the underlying error for logging, without exposing it
+// in the JSON response body.
+func (e *Error) WithCause(err error) *Error {
+	e.Cause = err
+	return e
+}
+
+// WithFields attaches field-level detail (e.g. validation errors) that is
+// safe to return to the client.
+func (e *Error) WithFields(fields map[string]any) *Error {
+	e.Fields = fields
+	return e
+}
+
+// FromBind wraps a gin c.BindJSON/ShouldBind error as a 400.
+func FromBind(err error) *Error {
+	return BadRequest("invalid_request", "request body could not be parsed").WithCause(err)
+}
+
+// FromMongo maps common Mongo driver errors to the right HTTP status,
+// defaulting to 500 for anything it doesn't recognise.
+func FromMongo(err error) *Error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return NotFound("not_found", "resource not found").WithCause(err)
+	}
+
+	var writeErr mongo.WriteException
+	if errors.As(err, &writeErr) {
+		for _, we := range writeErr.WriteErrors {
+			if we.Code == 11000 {
+				return Conflict("already_exists", "resource already exists").WithCause(err)
+			}
+		}
+	}
+
+	return Internal("internal_error", "internal server error").WithCause(err)
+}
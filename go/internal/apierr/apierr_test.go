@@ -0,0 +1,122 @@
+package apierr
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestConstructorsSetStatusAndCode(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    *Error
+		status int
+	}{
+		{"BadRequest", BadRequest("bad_input", "bad input"), http.StatusBadRequest},
+		{"Unauthorized", Unauthorized("unauthorized", "unauthorized access"), http.StatusUnauthorized},
+		{"Forbidden", Forbidden("forbidden", "forbidden"), http.StatusForbidden},
+		{"NotFound", NotFound("not_found", "resource not found"), http.StatusNotFound},
+		{"Conflict", Conflict("already_exists", "resource already exists"), http.StatusConflict},
+		{"Internal", Internal("internal_error", "internal server error"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.err.Status != tc.status {
+				t.Errorf("Status = %d, want %d", tc.err.Status, tc.status)
+			}
+			if tc.err.Code == "" {
+				t.Errorf("Code is empty")
+			}
+			if tc.err.Message == "" {
+				t.Errorf("Message is empty")
+			}
+		})
+	}
+}
+
+func TestErrorStringIncludesCause(t *testing.T) {
+	cause := errors.New("dial tcp: connection refused")
+	err := Internal("internal_error", "internal server error").WithCause(cause)
+
+	want := "internal server error: dial tcp: connection refused"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	if !errors.Is(err, cause) {
+		t.Errorf("errors.Is(err, cause) = false, want true (Unwrap should expose the cause)")
+	}
+}
+
+func TestErrorStringWithoutCause(t *testing.T) {
+	err := NotFound("not_found", "resource not found")
+	if got := err.Error(); got != "resource not found" {
+		t.Errorf("Error() = %q, want %q", got, "resource not found")
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	fields := map[string]any{"email": "is required"}
+	err := BadRequest("invalid_request", "request body could not be parsed").WithFields(fields)
+
+	if err.Fields["email"] != "is required" {
+		t.Errorf("Fields[%q] = %v, want %q", "email", err.Fields["email"], "is required")
+	}
+}
+
+func TestFromBind(t *testing.T) {
+	cause := errors.New("unexpected EOF")
+	err := FromBind(cause)
+
+	if err.Status != http.StatusBadRequest {
+		t.Errorf("Status = %d, want %d", err.Status, http.StatusBadRequest)
+	}
+	if err.Code != "invalid_request" {
+		t.Errorf("Code = %q, want %q", err.Code, "invalid_request")
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("errors.Is(err, cause) = false, want true")
+	}
+}
+
+func TestFromMongoNoDocuments(t *testing.T) {
+	err := FromMongo(mongo.ErrNoDocuments)
+
+	if err.Status != http.StatusNotFound {
+		t.Errorf("Status = %d, want %d", err.Status, http.StatusNotFound)
+	}
+	if err.Code != "not_found" {
+		t.Errorf("Code = %q, want %q", err.Code, "not_found")
+	}
+}
+
+func TestFromMongoDuplicateKey(t *testing.T) {
+	writeErr := mongo.WriteException{
+		WriteErrors: mongo.WriteErrors{
+			{Code: 11000, Message: "E11000 duplicate key error"},
+		},
+	}
+
+	err := FromMongo(writeErr)
+
+	if err.Status != http.StatusConflict {
+		t.Errorf("Status = %d, want %d", err.Status, http.StatusConflict)
+	}
+	if err.Code != "already_exists" {
+		t.Errorf("Code = %q, want %q", err.Code, "already_exists")
+	}
+}
+
+func TestFromMongoUnrecognizedFallsBackToInternal(t *testing.T) {
+	err := FromMongo(errors.New("some other mongo failure"))
+
+	if err.Status != http.StatusInternalServerError {
+		t.Errorf("Status = %d, want %d", err.Status, http.StatusInternalServerError)
+	}
+	if err.Code != "internal_error" {
+		t.Errorf("Code = %q, want %q", err.Code, "internal_error")
+	}
+}
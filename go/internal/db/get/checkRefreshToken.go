@@ -0,0 +1,35 @@
+package get
+
+import (
+	"context"
+	"time"
+
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+func FindRefreshTokenByHash(tokenHash string) (*mongodb.RefreshToken, error) {
+	c, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := GetCollections("refresh_tokens")
+	var token mongodb.RefreshToken
+
+	err := collection.FindOne(c, bson.M{"tokenHash": tokenHash}).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			logger.Log.Debug("Refresh token not found in database")
+			return nil, nil
+		}
+
+		logger.Log.Error("Failed to query refresh token from database",
+			zap.Error(err),
+			zap.String("collection", collection.Name()))
+		return nil, err
+	}
+
+	return &token, nil
+}
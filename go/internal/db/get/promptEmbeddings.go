@@ -0,0 +1,57 @@
+package get
+
+import (
+	"context"
+	"time"
+
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// FindPromptEmbedding looks up the stored vector for a prompt, or returns
+// (nil, nil) if it hasn't been embedded yet.
+func FindPromptEmbedding(promptID string) (*mongodb.PromptEmbedding, error) {
+	c, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := GetCollections("prompt_embeddings")
+	var embedding mongodb.PromptEmbedding
+
+	err := collection.FindOne(c, bson.M{"promptId": promptID}).Decode(&embedding)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		logger.Log.Error("Failed to query prompt embedding from database",
+			zap.Error(err), zap.String("promptId", promptID))
+		return nil, err
+	}
+
+	return &embedding, nil
+}
+
+// ListOutdatedPromptEmbeddings returns every embedding not produced by
+// currentModel, for the background re-embed job to refresh after a model
+// version bump.
+func ListOutdatedPromptEmbeddings(currentModel string) ([]mongodb.PromptEmbedding, error) {
+	c, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := GetCollections("prompt_embeddings")
+
+	cursor, err := collection.Find(c, bson.M{"model": bson.M{"$ne": currentModel}})
+	if err != nil {
+		logger.Log.Error("Failed to query outdated prompt embeddings", zap.Error(err))
+		return nil, err
+	}
+	defer cursor.Close(c)
+
+	var embeddings []mongodb.PromptEmbedding
+	if err := cursor.All(c, &embeddings); err != nil {
+		return nil, err
+	}
+	return embeddings, nil
+}
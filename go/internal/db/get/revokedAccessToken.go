@@ -0,0 +1,31 @@
+package get
+
+import (
+	"context"
+	"time"
+
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// IsAccessTokenRevoked reports whether jti has been revoked (e.g. by
+// logout), so JWTMiddleWare can reject it before its natural expiry.
+func IsAccessTokenRevoked(jti string) (bool, error) {
+	c, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	collection := GetCollections("revoked_access_tokens")
+
+	err := collection.FindOne(c, bson.M{"jti": jti}).Err()
+	if err == nil {
+		return true, nil
+	}
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	}
+
+	logger.Log.Error("Failed to check access token revocation", zap.Error(err), zap.String("jti", jti))
+	return false, err
+}
@@ -0,0 +1,38 @@
+package post
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/db/get"
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+func CreateRefreshToken(token mongodb.RefreshToken) (string, error) {
+	collection := get.GetCollections("refresh_tokens")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := collection.InsertOne(ctx, token)
+	if err != nil {
+		logger.Log.Error("Failed to insert the refresh token into Db",
+			zap.Error(err),
+			zap.String("UserID: ", token.UserID),
+			zap.String("Family: ", token.Family))
+		return "", err
+	}
+
+	logger.Log.Debug("Successfully created refresh token",
+		zap.Any("RefreshTokenID: ", result.InsertedID),
+	)
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		return oid.Hex(), nil
+	}
+	return fmt.Sprintf("%v", result.InsertedID), nil
+}
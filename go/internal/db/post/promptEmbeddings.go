@@ -0,0 +1,34 @@
+package post
+
+import (
+	"context"
+	"time"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/db/get"
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// SavePromptEmbedding upserts the vector for a prompt, keyed by PromptID —
+// called on prompt save, and again by the background re-embed job whenever
+// the active embeddings model changes.
+func SavePromptEmbedding(embedding mongodb.PromptEmbedding) error {
+	collection := get.GetCollections("prompt_embeddings")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"promptId": embedding.PromptID}
+	update := bson.M{"$set": embedding}
+
+	_, err := collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		logger.Log.Error("Failed to save prompt embedding",
+			zap.Error(err), zap.String("promptId", embedding.PromptID))
+		return err
+	}
+	return nil
+}
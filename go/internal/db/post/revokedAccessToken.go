@@ -0,0 +1,34 @@
+package post
+
+import (
+	"context"
+	"time"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/db/get"
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"go.uber.org/zap"
+)
+
+// RevokeAccessToken persists jti so it's rejected by JWTMiddleWare (via the
+// revocation cache) instead of staying valid until its own expiry. Called on
+// logout.
+func RevokeAccessToken(jti, userID string, expiresAt time.Time) error {
+	collection := get.GetCollections("revoked_access_tokens")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	record := mongodb.RevokedAccessToken{
+		JTI:       jti,
+		UserID:    userID,
+		RevokedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}
+
+	if _, err := collection.InsertOne(ctx, record); err != nil {
+		logger.Log.Error("Failed to persist revoked access token", zap.Error(err), zap.String("jti", jti))
+		return err
+	}
+	return nil
+}
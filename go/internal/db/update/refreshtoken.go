@@ -0,0 +1,50 @@
+package update
+
+import (
+	"context"
+	"time"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/db/get"
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// MarkRefreshTokenReplaced flags a rotated refresh token as replaced by the
+// newly issued one, so a second presentation of the old token is recognised
+// as reuse.
+func MarkRefreshTokenReplaced(id, replacedByID string) error {
+	collection := get.GetCollections("refresh_tokens")
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		logger.Log.Error("Refresh token id is not a valid ObjectID", zap.Error(err), zap.String("id", id))
+		return err
+	}
+
+	filter := bson.M{"_id": oid}
+	update := bson.M{"$set": bson.M{"replacedBy": replacedByID}}
+
+	if _, err := collection.UpdateOne(context.Background(), filter, update); err != nil {
+		logger.Log.Error("Failed to mark refresh token as replaced", zap.Error(err), zap.String("id", id))
+		return err
+	}
+	return nil
+}
+
+// RevokeRefreshTokenFamily revokes every refresh token that shares a family,
+// used both for logout and for reuse detection.
+func RevokeRefreshTokenFamily(userID, family string) error {
+	collection := get.GetCollections("refresh_tokens")
+
+	filter := bson.M{"userId": userID, "family": family, "revokedAt": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"revokedAt": time.Now()}}
+
+	_, err := collection.UpdateMany(context.Background(), filter, update)
+	if err != nil {
+		logger.Log.Error("Failed to revoke refresh token family", zap.Error(err), zap.String("userId", userID), zap.String("family", family))
+		return err
+	}
+	return nil
+}
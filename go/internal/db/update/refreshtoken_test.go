@@ -0,0 +1,65 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// TestMarkRefreshTokenReplacedFiltersByObjectID guards against a regression
+// where the update filter matched "_id" against the raw hex string instead
+// of the ObjectID Mongo actually stores it as, which meant the write never
+// matched a real document and refresh-token reuse detection silently never
+// persisted. Refresh tokens have no in-memory repo to test against (they go
+// through internal/db directly), so this talks to a mocked real driver.
+func TestMarkRefreshTokenReplacedFiltersByObjectID(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	defer mt.Close()
+
+	mt.Run("filters _id as ObjectID", func(mt *mtest.T) {
+		db.MongoDataBase = mt.DB
+		id := primitive.NewObjectID()
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse(
+			bson.E{Key: "n", Value: 1},
+			bson.E{Key: "nModified", Value: 1},
+		))
+
+		if err := MarkRefreshTokenReplaced(id.Hex(), "replacement-id"); err != nil {
+			t.Fatalf("MarkRefreshTokenReplaced returned error: %v", err)
+		}
+
+		evt := mt.GetStartedEvent()
+		if evt == nil {
+			t.Fatal("expected an update command to be sent")
+		}
+
+		updates, err := evt.Command.LookupErr("updates")
+		if err != nil {
+			t.Fatalf("update command has no 'updates' field: %v", err)
+		}
+		docs, err := updates.Array().Values()
+		if err != nil || len(docs) == 0 {
+			t.Fatalf("update command's 'updates' array is empty: %v", err)
+		}
+
+		filter, err := docs[0].Document().LookupErr("q")
+		if err != nil {
+			t.Fatalf("update document has no filter ('q'): %v", err)
+		}
+		filterID, err := filter.Document().LookupErr("_id")
+		if err != nil {
+			t.Fatalf("filter has no _id: %v", err)
+		}
+
+		if filterID.Type != bson.TypeObjectID {
+			t.Fatalf("filter _id type = %s, want ObjectID (filters by raw string never match a real document)", filterID.Type)
+		}
+		if got := filterID.ObjectID(); got != id {
+			t.Errorf("filter _id = %s, want %s", got.Hex(), id.Hex())
+		}
+	})
+}
@@ -0,0 +1,14 @@
+// Package embed turns prompt text into vectors for semantic reuse search,
+// behind a provider-agnostic Embedder so the app isn't locked to one vendor.
+package embed
+
+import "context"
+
+// Embedder turns text into a fixed-dimension vector. Model identifies which
+// model/version produced it, so mongodb.PromptEmbedding.Model can be
+// compared against Default.Model() to detect a stale embedding after a
+// model upgrade.
+type Embedder interface {
+	Embed(ctx context.Context, text string) (vector []float32, err error)
+	Model() string
+}
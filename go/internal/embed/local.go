@@ -0,0 +1,54 @@
+package embed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// localEmbedder calls a self-hosted sentence-transformers server (e.g. the
+// sentence-transformers/server reference implementation) over HTTP, so
+// embeddings can run without an external API dependency.
+type localEmbedder struct {
+	endpoint string
+	model    string
+}
+
+// NewLocalEmbedder builds an Embedder backed by a local sentence-transformers
+// HTTP server listening at endpoint.
+func NewLocalEmbedder(endpoint, model string) Embedder {
+	return &localEmbedder{endpoint: endpoint, model: model}
+}
+
+func (e *localEmbedder) Model() string { return e.model }
+
+func (e *localEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	body, err := json.Marshal(map[string]any{"inputs": text})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("embed: local: sentence-transformers server returned %d", resp.StatusCode)
+	}
+
+	var vector []float32
+	if err := json.NewDecoder(resp.Body).Decode(&vector); err != nil {
+		return nil, err
+	}
+	return vector, nil
+}
@@ -0,0 +1,61 @@
+package embed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const openAIEmbeddingsURL = "https://api.openai.com/v1/embeddings"
+
+// openAIEmbedder calls OpenAI's embeddings endpoint.
+type openAIEmbedder struct {
+	apiKey string
+	model  string
+}
+
+// NewOpenAIEmbedder builds an Embedder backed by OpenAI's embeddings API.
+func NewOpenAIEmbedder(apiKey, model string) Embedder {
+	return &openAIEmbedder{apiKey: apiKey, model: model}
+}
+
+func (e *openAIEmbedder) Model() string { return e.model }
+
+func (e *openAIEmbedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	body, err := json.Marshal(map[string]any{"model": e.model, "input": text})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, openAIEmbeddingsURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("embed: openai: embeddings endpoint returned %d", resp.StatusCode)
+	}
+
+	var decoded struct {
+		Data []struct {
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil, err
+	}
+	if len(decoded.Data) == 0 {
+		return nil, fmt.Errorf("embed: openai: response had no embeddings")
+	}
+	return decoded.Data[0].Embedding, nil
+}
@@ -0,0 +1,32 @@
+package embed
+
+import "os"
+
+// Default is the package-level Embedder every prompt-save/search path uses.
+// Setup must run before any of that code does.
+var Default Embedder
+
+// Setup builds Default from EMBEDDINGS_PROVIDER ("openai" or "local",
+// defaulting to "local" so dev doesn't need an OpenAI key). OPENAI_API_KEY/
+// OPENAI_EMBEDDINGS_MODEL configure the former; SENTENCE_TRANSFORMERS_URL/
+// SENTENCE_TRANSFORMERS_MODEL the latter.
+func Setup() {
+	switch os.Getenv("EMBEDDINGS_PROVIDER") {
+	case "openai":
+		model := os.Getenv("OPENAI_EMBEDDINGS_MODEL")
+		if model == "" {
+			model = "text-embedding-3-small"
+		}
+		Default = NewOpenAIEmbedder(os.Getenv("OPENAI_API_KEY"), model)
+	default:
+		endpoint := os.Getenv("SENTENCE_TRANSFORMERS_URL")
+		if endpoint == "" {
+			endpoint = "http://localhost:8000/embed"
+		}
+		model := os.Getenv("SENTENCE_TRANSFORMERS_MODEL")
+		if model == "" {
+			model = "all-MiniLM-L6-v2"
+		}
+		Default = NewLocalEmbedder(endpoint, model)
+	}
+}
@@ -1,11 +1,18 @@
 package handlers
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// AccessTokenTTL is kept short now that expiry is backstopped by the
+// refresh-token flow in RefreshToken/Logout.
+const AccessTokenTTL = 15 * time.Minute
+
 func CreateJWTToken(extra map[string]any) (string, error) {
 	claims := jwt.MapClaims{}
 
@@ -13,9 +20,47 @@ func CreateJWTToken(extra map[string]any) (string, error) {
 		claims[k] = v
 	}
 
+	jti, err := newJTI()
+	if err != nil {
+		return "", err
+	}
+
+	claims["jti"] = jti
 	claims["iat"] = time.Now().Unix()
-	claims["exp"] = time.Now().Add(24 * time.Hour).Unix()
+	claims["exp"] = time.Now().Add(AccessTokenTTL).Unix()
 
 	JwtStr := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return JwtStr.SignedString(JwtKey)
+}
+
+// newJTI generates the unique id every access token carries, so a logged-out
+// token can be revoked by identity rather than by value.
+func newJTI() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// NewRefreshToken generates an opaque refresh token and returns both the raw
+// value (sent to the client once) and its SHA-256 hash (the only thing that
+// gets persisted).
+func NewRefreshToken() (raw string, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	raw = hex.EncodeToString(buf)
+	sum := sha256.Sum256([]byte(raw))
+	hash = hex.EncodeToString(sum[:])
+	return raw, hash, nil
+}
+
+// HashRefreshToken re-derives the lookup hash for a raw refresh token
+// presented by a client.
+func HashRefreshToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
 }
\ No newline at end of file
@@ -1,210 +1,169 @@
 package handlers
 
 import (
-	"crypto/sha256"
-	"encoding/hex"
-	"fmt"
 	"net/http"
 	"os"
 	"time"
 
-	auth "github.com/SharveshRamchandani/aieduthon.git/internal/Auth"
-	"github.com/SharveshRamchandani/aieduthon.git/internal/db/get"
-	"github.com/SharveshRamchandani/aieduthon.git/internal/db/post"
-	"github.com/SharveshRamchandani/aieduthon.git/internal/db/update"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/apierr"
 	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
 	"github.com/SharveshRamchandani/aieduthon.git/internal/modals/login"
 	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/rbac"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/scope"
 	"github.com/gin-gonic/gin"
-	"github.com/markbates/goth/gothic"
 	"go.uber.org/zap"
 )
 
 var JwtKey = []byte(os.Getenv("JWT_SECRET"))
 
-func GoogleCallBackFunction(c *gin.Context) {
-	user, err := gothic.CompleteUserAuth(c.Writer, c.Request)
-	if err != nil {
-		logger.Log.Error("Failed to complete user Auth", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"Error": err})
-		return
-	}
-
-	if user.Email == "" {
-		logger.Log.Error("Failed to fetch user's email")
-		c.JSON(http.StatusBadRequest, gin.H{"Error": "Please provide a valid email!!"})
-		return
-	}
+func Login(repos *repo.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var Login login.Users
 
-	//Add to DB if new user or verify if they are existing user
-	exists, err := get.CheckUserExists(user.Email)
-	if err != nil{
-		logger.Log.Error("Failed to check the user's existance",
-		zap.Error(err),)
-		c.JSON(http.StatusInternalServerError, gin.H{"Error": "Internal Server Error"})
-		return
-	}
-
-	if exists == nil{
-		r := mongodb.Users{
-			UserName: user.Name,
-			Email: user.Email,
-			GoogleID: user.UserID,
-			AuthProvider: "google",
-			Organisation: "",
-			LastLogin: time.Now().Format("Monday, 02-Jan-06 15:04:05 MST"),
-			Createdat: time.DateOnly,
+		if err := c.BindJSON(&Login); err != nil {
+			logger.From(c.Request.Context()).Error("failed to read user data.", zap.Error(err))
+			c.Error(apierr.FromBind(err))
+			return
 		}
-		post.CreateUser(r)
-	}else{
-		logger.Log.Debug("User already exists", zap.String("email", user.Email))
-	}
 
-	JwtToken, err := CreateJWTToken(map[string]any{
-		"name":  user.Name,
-		"ID":    user.UserID,
-		"email": user.Email,
-	})
-
-	if err != nil {
-		logger.Log.Error("Failed to create JWT token", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server error"})
-		return
-	}
-
-	session, _ := auth.Store.Get(c.Request, "session")
-	session.Values["email"] = user.Email
-	_ = session.Save(c.Request, c.Writer)
-
-	frontendURL := os.Getenv("FRONTEND_URL")
-	if frontendURL == "" {
-		logger.Log.Error("FRONTEND_URL environment variable is not set")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server error"})
-		return
-	}
-
-	JwtExp := time.Now().Add(24 * time.Hour).Unix()
-
-	c.SetCookie(
-		"jwt",
-		JwtToken,
-		int(JwtExp),
-		"/",
-		"localhost",
-		false,
-		true,
-	)
-
-	logger.Log.Info("Frontend URL loaded", zap.String("url", frontendURL))
-
-	redirect := fmt.Sprintf("%s/home", frontendURL)
-	c.Redirect(http.StatusSeeOther, redirect)
-}
-
-func Login(c *gin.Context){
-	var Login login.Users
+		exists, err := repos.Users.FindByEmail(Login.UserEmail)
+		if err != nil {
+			c.Error(apierr.FromMongo(err))
+			return
+		}
 
-	if err := c.BindJSON(&Login); err != nil{
-		logger.Log.Error("failed to read user data.", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error":"Internal server error"})
-		return
-	}
+		if exists == nil {
+			logger.From(c.Request.Context()).Debug("User does not exist.", zap.String("Email: ", Login.UserEmail))
+			c.Error(apierr.NotFound("user_not_found", "user not found"))
+			return
+		}
 
-	exists, err := get.CheckUserExists(Login.UserEmail)
-	if err != nil{
-		logger.Log.Error("Failed to check the user's existance",
-		zap.Error(err),)
-		c.JSON(http.StatusInternalServerError, gin.H{"Error": "Internal Server Error"})
-		return
-	}
+		match, legacy, err := VerifyPassword(Login.Password, exists.PasswdHash)
+		if err != nil {
+			logger.From(c.Request.Context()).Error("Failed to verify password", zap.Error(err))
+			c.Error(apierr.Internal("internal_error", "internal server error").WithCause(err))
+			return
+		}
+		if !match {
+			logger.From(c.Request.Context()).Debug("Password does not match", zap.String("User: ", Login.UserEmail))
+			c.Error(apierr.Unauthorized("invalid_credentials", "password is incorrect"))
+			return
+		}
 
-	if exists == nil {
-		logger.Log.Error("User does not exist.", zap.String("Email: ", Login.UserEmail))
-		c.JSON(http.StatusBadRequest, gin.H{"Error": "User not found"})
-		return
-	}
+		if legacy {
+			// First successful login since the argon2id switch — upgrade the
+			// stored hash so this user isn't stuck on SHA-256 forever.
+			if upgraded, err := HashPassword(Login.Password); err != nil {
+				logger.From(c.Request.Context()).Error("Failed to upgrade legacy password hash", zap.Error(err))
+			} else if err := repos.Users.UpdatePasswordHash(exists.ID, upgraded); err != nil {
+				logger.From(c.Request.Context()).Error("Failed to persist upgraded password hash", zap.Error(err))
+			}
+		}
 
-	hashed := sha256.Sum256([]byte(Login.Password))
-	HexHash := hex.EncodeToString(hashed[:])
+		JWTToken, err := CreateJWTToken(map[string]any{
+			"Name":  exists.UserName,
+			"ID":    exists.ID,
+			"email": exists.Email,
+			"roles": exists.Roles,
+			"scope": scope.Join(exists.Scopes),
+		})
+
+		if err != nil {
+			logger.From(c.Request.Context()).Error("Failed to create JWT token", zap.Error(err))
+			c.Error(apierr.Internal("token_creation_failed", "internal server error").WithCause(err))
+			return
+		}
 
-	if HexHash != exists.PasswdHash{
-		logger.Log.Error("Password does not match", zap.String("User: ",Login.UserEmail))
-		c.JSON(http.StatusBadRequest, gin.H{"Error" : "Password is incorrect"})
-		return
-	}
+		refreshToken, err := IssueRefreshToken(c.Request.Context(), exists.ID, c.Request.UserAgent(), c.ClientIP())
+		if err != nil {
+			logger.From(c.Request.Context()).Error("Failed to issue refresh token", zap.Error(err))
+			c.Error(apierr.Internal("token_creation_failed", "internal server error").WithCause(err))
+			return
+		}
 
-	JWTToken, err := CreateJWTToken(map[string]any{
-		"Name": exists.UserName,
-		"ID": exists.ID,
-		"email": exists.Email,
-	})
+		if err := repos.Users.UpdateLastLogin(Login.UserEmail); err != nil {
+			logger.From(c.Request.Context()).Error("Failed to update login time of user", zap.Error(err), zap.String("Email: ", Login.UserEmail))
+		}
 
-	if err != nil {
-		logger.Log.Error("Failed to create JWT token", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server error"})
-		return
+		JwtExp := time.Now().Add(AccessTokenTTL).Unix()
+
+		c.SetCookie(
+			"jwt",
+			JWTToken,
+			int(JwtExp),
+			"/",
+			"localhost",
+			false,
+			true,
+		)
+		c.SetCookie(
+			"refresh_token",
+			refreshToken,
+			int(RefreshTokenTTL.Seconds()),
+			"/",
+			"localhost",
+			false,
+			true,
+		)
+
+		logger.From(c.Request.Context()).Debug("User successfully logged in", zap.String("User : ", exists.UserName))
+		c.JSON(http.StatusAccepted, gin.H{"Message": "Successfully LoggedIn"})
 	}
-
-	update.UpdateLoginTime(Login)
-
-	JwtExp := time.Now().Add(24 * time.Hour).Unix()
-
-	c.SetCookie(
-		"jwt",
-		JWTToken,
-		int(JwtExp),
-		"/",
-		"localhost",
-		false,
-		true,
-	)
-
-	logger.Log.Debug("User successfully logged in", zap.String("User : ", exists.UserName))
-	c.JSON(http.StatusAccepted, gin.H{"Message" : "Successfully LoggedIn"})
 }
 
-func SignUp(c *gin.Context){
-	var signup login.SignUp
+func SignUp(repos *repo.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var signup login.SignUp
 
-	if err := c.BindJSON(&signup); err != nil {
-		logger.Log.Error("Failed to fetch the user details", zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error" : "Internal server error"})
-		return
-	}
+		if err := c.BindJSON(&signup); err != nil {
+			logger.From(c.Request.Context()).Error("Failed to fetch the user details", zap.Error(err))
+			c.Error(apierr.FromBind(err))
+			return
+		}
 
-	exists, err := get.CheckUserExists(signup.Email)
-	if err != nil {
-		logger.Log.Error("Failed to fetch user from DB" , zap.Error(err))
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "Internal Server Error"})
-		return
-	}
+		exists, err := repos.Users.FindByEmail(signup.Email)
+		if err != nil {
+			c.Error(apierr.FromMongo(err))
+			return
+		}
 
-	hashed := sha256.Sum256([]byte(signup.Password))
-	HexHashed := hex.EncodeToString(hashed[:])
-
-	if exists == nil{
-		r := mongodb.Users{
-			UserName: signup.Name,
-			Email: signup.Email,
-			PasswdHash: HexHashed,
-			AuthProvider: "Local",
-			Organisation: "",
-			LastLogin: time.Now().Format("Monday, 02-Jan-06 15:04:05 MST"),
-			Createdat: time.DateOnly,
+		if exists == nil {
+			hashed, err := HashPassword(signup.Password)
+			if err != nil {
+				logger.From(c.Request.Context()).Error("Failed to hash password", zap.Error(err))
+				c.Error(apierr.Internal("internal_error", "internal server error").WithCause(err))
+				return
+			}
+
+			r := mongodb.Users{
+				UserName:     signup.Name,
+				Email:        signup.Email,
+				PasswdHash:   hashed,
+				AuthProvider: "Local",
+				Organisation: "",
+				LastLogin:    time.Now().Format("Monday, 02-Jan-06 15:04:05 MST"),
+				Createdat:    time.DateOnly,
+				Roles:        rbac.DefaultRoles,
+				Scopes:       scope.DefaultScopes,
+			}
+			if _, err := repos.Users.Create(r); err != nil {
+				logger.From(c.Request.Context()).Error("Failed to insert the user into Db", zap.Error(err), zap.String("Email: ", signup.Email))
+			}
+		} else {
+			logger.From(c.Request.Context()).Debug("User already exists", zap.String("User : ", exists.UserName))
 		}
-		post.CreateUser(r)
-	}else {
-		logger.Log.Debug("User already exists", zap.String("User : ", exists.UserName))
-	}
 
-	loginURL := os.Getenv("LOGIN_URL")
-	if loginURL == ""{
-		logger.Log.Error("Failed to fetch login url", zap.String("URL: ", loginURL))
-		c.JSON(http.StatusInternalServerError, gin.H{"error" : "Internal server error"})
-		return
-	}
+		loginURL := os.Getenv("LOGIN_URL")
+		if loginURL == "" {
+			logger.From(c.Request.Context()).Error("Failed to fetch login url", zap.String("URL: ", loginURL))
+			c.Error(apierr.Internal("config_missing", "internal server error"))
+			return
+		}
 
-	logger.Log.Debug("Login URL: " + loginURL)
-	logger.Log.Debug("User SignedUp successfully", zap.String("User: ", signup.Name), zap.String("Email: ", signup.Email))
-	c.Redirect(http.StatusSeeOther, loginURL)
-}
\ No newline at end of file
+		logger.From(c.Request.Context()).Debug("Login URL: " + loginURL)
+		logger.From(c.Request.Context()).Debug("User SignedUp successfully", zap.String("User: ", signup.Name), zap.String("Email: ", signup.Email))
+		c.Redirect(http.StatusSeeOther, loginURL)
+	}
+}
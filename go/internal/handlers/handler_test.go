@@ -0,0 +1,182 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/handlers"
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/middleware"
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	inmemoryrepo "github.com/SharveshRamchandani/aieduthon.git/internal/repo/inmemory"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+	logger.Log = zap.NewNop()
+}
+
+func newErrorAwareRouter() *gin.Engine {
+	r := gin.New()
+	r.Use(middleware.RequestLogger(), middleware.ErrorHandler())
+	return r
+}
+
+func doJSON(t *testing.T, r *gin.Engine, method, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatalf("failed to encode request body: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+// SignUp only ever touches repos.Users, so it's fully coverable against the
+// in-memory repo.
+func TestSignUp(t *testing.T) {
+	t.Setenv("LOGIN_URL", "https://example.com/login")
+
+	cases := []struct {
+		name       string
+		seedUser   *mongodb.Users
+		body       map[string]string
+		wantStatus int
+	}{
+		{
+			name:       "new user",
+			body:       map[string]string{"name": "Ada", "email": "ada@example.com", "password": "s3cret"},
+			wantStatus: http.StatusSeeOther,
+		},
+		{
+			name:       "already registered email still redirects",
+			seedUser:   &mongodb.Users{UserName: "Ada", Email: "ada@example.com", PasswdHash: "whatever"},
+			body:       map[string]string{"name": "Ada", "email": "ada@example.com", "password": "s3cret"},
+			wantStatus: http.StatusSeeOther,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repos := inmemoryrepo.NewContainer()
+			if tc.seedUser != nil {
+				if _, err := repos.Users.Create(*tc.seedUser); err != nil {
+					t.Fatalf("failed to seed user: %v", err)
+				}
+			}
+
+			r := newErrorAwareRouter()
+			r.POST("/signup", handlers.SignUp(repos))
+
+			rec := doJSON(t, r, http.MethodPost, "/signup", tc.body)
+			if rec.Code != tc.wantStatus {
+				t.Errorf("status = %d, want %d (body: %s)", rec.Code, tc.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestSignUpMissingLoginURL(t *testing.T) {
+	t.Setenv("LOGIN_URL", "")
+
+	repos := inmemoryrepo.NewContainer()
+	r := newErrorAwareRouter()
+	r.POST("/signup", handlers.SignUp(repos))
+
+	rec := doJSON(t, r, http.MethodPost, "/signup", map[string]string{
+		"name": "Ada", "email": "ada@example.com", "password": "s3cret",
+	})
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d (body: %s)", rec.Code, http.StatusInternalServerError, rec.Body.String())
+	}
+}
+
+// Login's happy path issues a refresh token via internal/db/post, which talks
+// to Mongo directly rather than through repo.Container — so only the error
+// paths that return before that point are coverable here without a live
+// database.
+func TestLoginErrorPaths(t *testing.T) {
+	hashed, err := handlers.HashPassword("correct-password")
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+
+	cases := []struct {
+		name       string
+		seedUser   *mongodb.Users
+		body       map[string]string
+		wantStatus int
+	}{
+		{
+			name:       "malformed body",
+			body:       nil,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "user not found",
+			body:       map[string]string{"email": "nobody@example.com", "password": "whatever"},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "wrong password",
+			seedUser:   &mongodb.Users{UserName: "Ada", Email: "ada@example.com", PasswdHash: hashed},
+			body:       map[string]string{"email": "ada@example.com", "password": "wrong-password"},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repos := inmemoryrepo.NewContainer()
+			if tc.seedUser != nil {
+				if _, err := repos.Users.Create(*tc.seedUser); err != nil {
+					t.Fatalf("failed to seed user: %v", err)
+				}
+			}
+
+			r := newErrorAwareRouter()
+			r.POST("/login", handlers.Login(repos))
+
+			var rec *httptest.ResponseRecorder
+			if tc.body == nil {
+				req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewBufferString("{not json"))
+				req.Header.Set("Content-Type", "application/json")
+				rec = httptest.NewRecorder()
+				r.ServeHTTP(rec, req)
+			} else {
+				rec = doJSON(t, r, http.MethodPost, "/login", tc.body)
+			}
+
+			if rec.Code != tc.wantStatus {
+				t.Errorf("status = %d, want %d (body: %s)", rec.Code, tc.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+// RefreshToken also reaches into internal/db/get directly for everything
+// past the initial presence check, so only that first validation step is
+// coverable without a live database.
+func TestRefreshTokenMissingToken(t *testing.T) {
+	repos := inmemoryrepo.NewContainer()
+	r := newErrorAwareRouter()
+	r.POST("/auth/refresh", handlers.RefreshToken(repos))
+
+	rec := doJSON(t, r, http.MethodPost, "/auth/refresh", map[string]string{})
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d (body: %s)", rec.Code, http.StatusBadRequest, rec.Body.String())
+	}
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	auth "github.com/SharveshRamchandani/aieduthon.git/internal/Auth"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/apierr"
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/rbac"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/scope"
+	"github.com/gin-gonic/gin"
+	"github.com/markbates/goth"
+	"github.com/markbates/goth/gothic"
+	"go.uber.org/zap"
+)
+
+// OAuthCallback completes the goth auth flow for whichever provider is named
+// in the :provider route param, upserts the user into mongodb.Users, and
+// issues the same JWT+refresh pair as password login. It replaces the old
+// Google-only GoogleCallBackFunction now that SetUpgoth registers a
+// provider-agnostic set via auth.Provider.
+func OAuthCallback(repos *repo.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		gothUser, err := gothic.CompleteUserAuth(c.Writer, c.Request)
+		if err != nil {
+			c.Error(apierr.Internal("oauth_callback_failed", "internal server error").WithCause(err))
+			return
+		}
+
+		if gothUser.Email == "" {
+			c.Error(apierr.BadRequest("email_required", "please provide a valid email"))
+			return
+		}
+
+		provider := c.Param("provider")
+
+		user, err := upsertOAuthUser(c.Request.Context(), repos, provider, gothUser)
+		if err != nil {
+			c.Error(apierr.Conflict("provider_conflict", err.Error()))
+			return
+		}
+
+		JwtToken, err := CreateJWTToken(map[string]any{
+			"name":  user.UserName,
+			"ID":    user.ID,
+			"email": user.Email,
+			"roles": user.Roles,
+			"scope": scope.Join(user.Scopes),
+		})
+		if err != nil {
+			c.Error(apierr.Internal("token_creation_failed", "internal server error").WithCause(err))
+			return
+		}
+
+		refreshToken, err := IssueRefreshToken(c.Request.Context(), user.ID, c.Request.UserAgent(), c.ClientIP())
+		if err != nil {
+			c.Error(apierr.Internal("token_creation_failed", "internal server error").WithCause(err))
+			return
+		}
+
+		session, _ := auth.Store.Get(c.Request, "session")
+		session.Values["email"] = user.Email
+		_ = session.Save(c.Request, c.Writer)
+
+		frontendURL := os.Getenv("FRONTEND_URL")
+		if frontendURL == "" {
+			c.Error(apierr.Internal("config_missing", "internal server error"))
+			return
+		}
+
+		JwtExp := time.Now().Add(AccessTokenTTL).Unix()
+
+		c.SetCookie("jwt", JwtToken, int(JwtExp), "/", "localhost", false, true)
+		c.SetCookie("refresh_token", refreshToken, int(RefreshTokenTTL.Seconds()), "/", "localhost", false, true)
+
+		logger.From(c.Request.Context()).Info("Frontend URL loaded", zap.String("url", frontendURL))
+
+		redirect := fmt.Sprintf("%s/home", frontendURL)
+		c.Redirect(http.StatusSeeOther, redirect)
+	}
+}
+
+// upsertOAuthUser creates the user on first sign-in, or, for a returning
+// user, rejects the callback when the email is already bound to a different
+// provider — unless that provider was explicitly linked beforehand via
+// POST /auth/link/:provider.
+func upsertOAuthUser(ctx context.Context, repos *repo.Container, provider string, gothUser goth.User) (*mongodb.Users, error) {
+	exists, err := repos.Users.FindByEmail(gothUser.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists == nil {
+		u := mongodb.Users{
+			UserName:     gothUser.Name,
+			Email:        gothUser.Email,
+			AuthProvider: provider,
+			ProviderIDs:  map[string]string{provider: gothUser.UserID},
+			Organisation: "",
+			LastLogin:    time.Now().Format("Monday, 02-Jan-06 15:04:05 MST"),
+			Createdat:    time.DateOnly,
+			Roles:        rbac.DefaultRoles,
+			Scopes:       scope.DefaultScopes,
+		}
+		id, err := repos.Users.Create(u)
+		if err != nil {
+			return nil, err
+		}
+		u.ID = id
+		return &u, nil
+	}
+
+	logger.From(ctx).Debug("User already exists", zap.String("email", exists.Email))
+
+	if _, linked := exists.ProviderIDs[provider]; linked {
+		return exists, nil
+	}
+
+	if exists.AuthProvider != provider {
+		return nil, fmt.Errorf("email is already registered with %s; link %s explicitly via /auth/link/%s", exists.AuthProvider, provider, provider)
+	}
+
+	return exists, nil
+}
+
+// LinkProvider handles POST /auth/link/:provider (authenticated): it
+// completes the goth flow and attaches the provider's ID to the current
+// JWT's user instead of creating a new account.
+func LinkProvider(repos *repo.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims := c.MustGet("claims").(map[string]any)
+		userID, _ := claims["ID"].(string)
+		if userID == "" {
+			c.Error(apierr.Unauthorized("unauthorized", "unauthorized access"))
+			return
+		}
+
+		gothUser, err := gothic.CompleteUserAuth(c.Writer, c.Request)
+		if err != nil {
+			c.Error(apierr.Internal("oauth_callback_failed", "internal server error").WithCause(err))
+			return
+		}
+
+		provider := c.Param("provider")
+
+		if err := repos.Users.LinkProvider(userID, provider, gothUser.UserID); err != nil {
+			c.Error(apierr.FromMongo(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("%s linked successfully", provider)})
+	}
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// argon2Params controls the cost of newly created password hashes. Every
+// field is overridable via env so ops can retune cost without a deploy.
+type argon2Params struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+	keyLen  uint32
+	saltLen uint32
+}
+
+func loadArgon2Params() argon2Params {
+	return argon2Params{
+		memory:  envUint32("ARGON2_MEMORY_KB", 64*1024),
+		time:    envUint32("ARGON2_TIME", 3),
+		threads: uint8(envUint32("ARGON2_THREADS", 2)),
+		keyLen:  envUint32("ARGON2_KEY_LEN", 32),
+		saltLen: envUint32("ARGON2_SALT_LEN", 16),
+	}
+}
+
+func envUint32(key string, def uint32) uint32 {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil {
+		return def
+	}
+	return uint32(v)
+}
+
+// HashPassword returns an argon2id hash encoded as
+// "argon2id$memory$time$threads$salt-hex$hash-hex" so VerifyPassword can
+// tell it apart from the legacy plain SHA-256 hex digest without a schema
+// change to Users.PasswdHash.
+func HashPassword(password string) (string, error) {
+	params := loadArgon2Params()
+
+	salt := make([]byte, params.saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, params.keyLen)
+
+	return fmt.Sprintf("argon2id$%d$%d$%d$%s$%s",
+		params.memory, params.time, params.threads,
+		hex.EncodeToString(salt), hex.EncodeToString(key)), nil
+}
+
+// VerifyPassword checks password against stored, which may be a modern
+// "argon2id$..." hash or a legacy plain SHA-256 hex digest left over from
+// before argon2id. legacy reports the latter, so Login can upgrade it to
+// argon2id on a successful check.
+func VerifyPassword(password, stored string) (ok bool, legacy bool, err error) {
+	if !strings.HasPrefix(stored, "argon2id$") {
+		hashed := sha256.Sum256([]byte(password))
+		return hex.EncodeToString(hashed[:]) == stored, true, nil
+	}
+
+	parts := strings.Split(stored, "$")
+	if len(parts) != 6 {
+		return false, false, fmt.Errorf("malformed argon2id hash")
+	}
+
+	memory, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return false, false, err
+	}
+	timeCost, err := strconv.ParseUint(parts[2], 10, 32)
+	if err != nil {
+		return false, false, err
+	}
+	threads, err := strconv.ParseUint(parts[3], 10, 8)
+	if err != nil {
+		return false, false, err
+	}
+	salt, err := hex.DecodeString(parts[4])
+	if err != nil {
+		return false, false, err
+	}
+	want, err := hex.DecodeString(parts[5])
+	if err != nil {
+		return false, false, err
+	}
+
+	got := argon2.IDKey([]byte(password), salt, uint32(timeCost), uint32(memory), uint8(threads), uint32(len(want)))
+	return subtle.ConstantTimeCompare(got, want) == 1, false, nil
+}
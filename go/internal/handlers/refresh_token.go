@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/db/get"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/db/post"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/db/update"
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/scope"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+// RefreshTokenTTL controls how long an issued refresh token is valid for
+// before the client must re-authenticate with credentials.
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// IssueRefreshToken mints a brand new refresh-token family for a user (used
+// on Login, the OAuth and OIDC callbacks, and WebAuthn login) and persists
+// its hash.
+func IssueRefreshToken(ctx context.Context, userID, userAgent, ip string) (raw string, err error) {
+	family, err := newFamilyID()
+	if err != nil {
+		return "", err
+	}
+	return issueRefreshTokenInFamily(ctx, userID, family, userAgent, ip)
+}
+
+func newFamilyID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func issueRefreshTokenInFamily(ctx context.Context, userID, family, userAgent, ip string) (string, error) {
+	raw, hash, err := NewRefreshToken()
+	if err != nil {
+		logger.From(ctx).Error("Failed to generate refresh token", zap.Error(err))
+		return "", err
+	}
+
+	now := time.Now()
+	record := mongodb.RefreshToken{
+		UserID:    userID,
+		TokenHash: hash,
+		Family:    family,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(RefreshTokenTTL),
+		UserAgent: userAgent,
+		IP:        ip,
+	}
+
+	if _, err := post.CreateRefreshToken(record); err != nil {
+		return "", err
+	}
+
+	return raw, nil
+}
+
+// RefreshToken handles POST /auth/refresh: it validates the presented
+// refresh token, rotates it, and returns a fresh access+refresh pair. Reuse
+// of an already-rotated token revokes the whole family.
+func RefreshToken(repos *repo.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req refreshRequest
+		if err := c.BindJSON(&req); err != nil {
+			if cookie, cerr := c.Cookie("refresh_token"); cerr == nil {
+				req.RefreshToken = cookie
+			}
+		}
+
+		if req.RefreshToken == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "refresh token is required"})
+			return
+		}
+
+		hash := HashRefreshToken(req.RefreshToken)
+		existing, err := get.FindRefreshTokenByHash(hash)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+
+		if existing == nil || !existing.RevokedAt.IsZero() || time.Now().After(existing.ExpiresAt) {
+			logger.From(c.Request.Context()).Debug("Refresh token rejected: unknown, revoked or expired")
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "refresh token is invalid or expired"})
+			return
+		}
+
+		if existing.ReplacedBy != "" {
+			// This token was already rotated once — presenting it again means
+			// either the old token leaked or the rotation response was lost.
+			// Treat it as compromise and kill the whole family.
+			logger.From(c.Request.Context()).Error("Refresh token reuse detected, revoking family",
+				zap.String("userId", existing.UserID), zap.String("family", existing.Family))
+			_ = update.RevokeRefreshTokenFamily(existing.UserID, existing.Family)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "refresh token has already been used"})
+			return
+		}
+
+		user, err := repos.Users.FindByID(existing.UserID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+		if user == nil {
+			logger.From(c.Request.Context()).Error("Refresh token points at a user that no longer exists", zap.String("userId", existing.UserID))
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "refresh token is invalid or expired"})
+			return
+		}
+
+		newRaw, newHash, err := NewRefreshToken()
+		if err != nil {
+			logger.From(c.Request.Context()).Error("Failed to generate refresh token", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+
+		now := time.Now()
+		nextRecord := mongodb.RefreshToken{
+			UserID:    existing.UserID,
+			TokenHash: newHash,
+			Family:    existing.Family,
+			IssuedAt:  now,
+			ExpiresAt: now.Add(RefreshTokenTTL),
+			UserAgent: c.Request.UserAgent(),
+			IP:        c.ClientIP(),
+		}
+		nextID, err := post.CreateRefreshToken(nextRecord)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+
+		if err := update.MarkRefreshTokenReplaced(existing.ID, nextID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+
+		accessToken, err := CreateJWTToken(map[string]any{
+			"Name":  user.UserName,
+			"ID":    user.ID,
+			"email": user.Email,
+			"roles": user.Roles,
+			"scope": scope.Join(user.Scopes),
+		})
+		if err != nil {
+			logger.From(c.Request.Context()).Error("Failed to create JWT token", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"access_token": accessToken, "refresh_token": newRaw})
+	}
+}
+
+// Logout handles POST /auth/logout by revoking the presented refresh
+// token's entire family so every device sharing it is signed out, and by
+// revoking the current access token's jti so it stops working instead of
+// lingering until its own (short) expiry. A process that has already cached
+// the jti as not revoked keeps accepting it until that revocation.Cache
+// entry's TTL runs out.
+func Logout(c *gin.Context) {
+	revokeCurrentAccessToken(c)
+
+	var req refreshRequest
+	if err := c.BindJSON(&req); err != nil {
+		if cookie, cerr := c.Cookie("refresh_token"); cerr == nil {
+			req.RefreshToken = cookie
+		}
+	}
+
+	if req.RefreshToken == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "refresh token is required"})
+		return
+	}
+
+	existing, err := get.FindRefreshTokenByHash(HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	if existing == nil {
+		c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+		return
+	}
+
+	if err := update.RevokeRefreshTokenFamily(existing.UserID, existing.Family); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	c.SetCookie("refresh_token", "", -1, "/", "localhost", false, true)
+	c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+}
+
+// extractAccessToken pulls the bearer JWT out of the Authorization header or
+// the "jwt" cookie, mirroring JWTMiddleWare's own extraction. handlers can't
+// import internal/middleware (it already imports handlers), hence the
+// duplication rather than a shared helper.
+func extractAccessToken(c *gin.Context) string {
+	auth := c.GetHeader("Authorization")
+	if strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	if cookie, err := c.Cookie("jwt"); err == nil {
+		return cookie
+	}
+	return ""
+}
+
+// revokeCurrentAccessToken best-effort revokes the access token presented
+// with this request, if any. A missing, already-expired or otherwise
+// unparsable token doesn't need revoking — it's already unusable.
+func revokeCurrentAccessToken(c *gin.Context) {
+	tokenString := extractAccessToken(c)
+	if tokenString == "" {
+		return
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		return JwtKey, nil
+	}); err != nil {
+		return
+	}
+
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return
+	}
+	userID, _ := claims["ID"].(string)
+
+	expiresAt := time.Now().Add(AccessTokenTTL)
+	if exp, ok := claims["exp"].(float64); ok {
+		expiresAt = time.Unix(int64(exp), 0)
+	}
+
+	if err := post.RevokeAccessToken(jti, userID, expiresAt); err != nil {
+		logger.From(c.Request.Context()).Error("Failed to revoke access token on logout", zap.Error(err), zap.String("jti", jti))
+	}
+}
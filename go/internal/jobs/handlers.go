@@ -0,0 +1,121 @@
+package jobs
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/apierr"
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"github.com/gin-gonic/gin"
+)
+
+type enqueueRequest struct {
+	ServiceType string         `json:"serviceType" binding:"required"`
+	Payload     map[string]any `json:"payload"`
+}
+
+// ownsJob reports whether the authenticated caller is the user who enqueued
+// job, so Status/Stream can't be used to read or tail someone else's job by
+// guessing its id.
+func ownsJob(c *gin.Context, job *mongodb.Job) bool {
+	claims := c.MustGet("claims").(map[string]any)
+	userID, _ := claims["ID"].(string)
+	return userID != "" && userID == job.UserID
+}
+
+// Enqueue handles POST /api/jobs: it accepts a generation request and hands
+// back a JobID the caller polls or streams for progress.
+func Enqueue(queue Queue) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body enqueueRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.Error(apierr.FromBind(err))
+			return
+		}
+
+		claims := c.MustGet("claims").(map[string]any)
+		userID, _ := claims["ID"].(string)
+
+		job := mongodb.Job{
+			UserID:      userID,
+			ServiceType: body.ServiceType,
+			Status:      StatusPending,
+			Payload:     body.Payload,
+		}
+
+		jobID, err := queue.Enqueue(c.Request.Context(), job)
+		if err != nil {
+			c.Error(apierr.FromMongo(err))
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{"jobId": jobID})
+	}
+}
+
+// Status handles GET /api/jobs/:id: a single snapshot of the job's current
+// status, progress and result.
+func Status(jobs repo.JobRepo) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		job, err := jobs.FindByID(c.Param("id"))
+		if err != nil {
+			c.Error(apierr.FromMongo(err))
+			return
+		}
+		if job == nil || !ownsJob(c, job) {
+			// Same response for "doesn't exist" and "isn't yours" so job IDs
+			// can't be enumerated by probing which ones 403 vs 404.
+			c.Error(apierr.NotFound("job_not_found", "job not found"))
+			return
+		}
+
+		c.JSON(http.StatusOK, job)
+	}
+}
+
+// Stream handles GET /api/jobs/:id/stream: it tails the job's status and
+// progress over server-sent events until it reaches a terminal state or the
+// client disconnects.
+func Stream(jobs repo.JobRepo, queue Queue) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		job, err := jobs.FindByID(id)
+		if err != nil {
+			c.Error(apierr.FromMongo(err))
+			return
+		}
+		if job == nil || !ownsJob(c, job) {
+			c.Error(apierr.NotFound("job_not_found", "job not found"))
+			return
+		}
+
+		updates, unsubscribe, err := queue.Subscribe(c.Request.Context(), id)
+		if err != nil {
+			c.Error(apierr.FromMongo(err))
+			return
+		}
+		defer unsubscribe()
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+
+		c.Stream(func(w gin.ResponseWriter) bool {
+			select {
+			case job, ok := <-updates:
+				if !ok {
+					return false
+				}
+				c.SSEvent("job", job)
+				return job.Status != StatusCompleted && job.Status != StatusFailed
+			case <-c.Request.Context().Done():
+				return false
+			case <-time.After(30 * time.Second):
+				c.SSEvent("ping", "")
+				return true
+			}
+		})
+	}
+}
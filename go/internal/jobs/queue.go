@@ -0,0 +1,79 @@
+// Package jobs runs long-lived slide/quiz/translation generation off the
+// request path: handlers enqueue a Job, a Worker pool claims and runs it
+// against a registered Handler, and the SSE endpoint tails its progress.
+package jobs
+
+import (
+	"context"
+	"time"
+
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+)
+
+// Job status values. These are stored verbatim in mongodb.Job.Status.
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+)
+
+// Queue is what Worker pulls work from. The default implementation is
+// backed by the existing jobs Mongo collection via repo.JobRepo; a
+// Redis-backed Queue (BRPOPLPUSH) can implement the same interface for
+// lower claim latency without touching Worker or the HTTP handlers.
+type Queue interface {
+	Enqueue(ctx context.Context, job mongodb.Job) (string, error)
+	Claim(ctx context.Context) (*mongodb.Job, error)
+	UpdateProgress(ctx context.Context, id string, pct int, msg string) error
+	Complete(ctx context.Context, id, resultURL string) error
+	Fail(ctx context.Context, id, errMsg string) error
+	// RequeueStale moves jobs claimed longer than leaseTTL ago back to
+	// pending — called once on worker startup to recover from a crash.
+	RequeueStale(ctx context.Context, leaseTTL time.Duration) (int, error)
+	Subscribe(ctx context.Context, id string) (<-chan mongodb.Job, func(), error)
+}
+
+type mongoQueue struct {
+	jobs repo.JobRepo
+}
+
+// NewMongoQueue wraps a repo.JobRepo (the jobs collection) as a Queue.
+func NewMongoQueue(jobs repo.JobRepo) Queue {
+	return &mongoQueue{jobs: jobs}
+}
+
+func (q *mongoQueue) Enqueue(ctx context.Context, job mongodb.Job) (string, error) {
+	if job.Status == "" {
+		job.Status = StatusPending
+	}
+	if job.CreatedAt.IsZero() {
+		job.CreatedAt = time.Now()
+	}
+	return q.jobs.Create(job)
+}
+
+func (q *mongoQueue) Claim(ctx context.Context) (*mongodb.Job, error) {
+	return q.jobs.ClaimNext(ctx)
+}
+
+func (q *mongoQueue) UpdateProgress(ctx context.Context, id string, pct int, msg string) error {
+	return q.jobs.UpdateProgress(id, pct, msg)
+}
+
+func (q *mongoQueue) Complete(ctx context.Context, id, resultURL string) error {
+	return q.jobs.UpdateStatus(id, StatusCompleted, resultURL, "")
+}
+
+func (q *mongoQueue) Fail(ctx context.Context, id, errMsg string) error {
+	return q.jobs.UpdateStatus(id, StatusFailed, "", errMsg)
+}
+
+func (q *mongoQueue) RequeueStale(ctx context.Context, leaseTTL time.Duration) (int, error) {
+	return q.jobs.RequeueStale(ctx, time.Now().Add(-leaseTTL))
+}
+
+func (q *mongoQueue) Subscribe(ctx context.Context, id string) (<-chan mongodb.Job, func(), error) {
+	return q.jobs.Subscribe(id)
+}
@@ -0,0 +1,45 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/prompts"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"go.uber.org/zap"
+)
+
+const (
+	workerConcurrency = 4
+	jobTimeout        = 5 * time.Minute
+	leaseTTL          = 2 * time.Minute
+)
+
+// NewWorkerPool builds the Queue on top of repos.Jobs and a Worker with the
+// generation handlers this codebase currently knows how to run registered.
+// The caller is responsible for Start/Shutdown.
+func NewWorkerPool(repos *repo.Container) *Worker {
+	queue := NewMongoQueue(repos.Jobs)
+	worker := NewWorker(queue, workerConcurrency, jobTimeout, leaseTTL)
+
+	worker.Register("slide.generate", notImplementedHandler("slide.generate"))
+	worker.Register("quiz.generate", notImplementedHandler("quiz.generate"))
+	worker.Register("translation", notImplementedHandler("translation"))
+	worker.Register("prompt.reembed", prompts.ReembedHandler(repos))
+
+	return worker
+}
+
+// notImplementedHandler fails the job immediately. The slide/quiz/translation
+// generators themselves don't exist yet in this codebase; registering a
+// handler that says so keeps the queue/worker plumbing usable end-to-end
+// (enqueue, claim, status, SSE) ahead of that work landing.
+func notImplementedHandler(serviceType string) Handler {
+	return func(ctx context.Context, job mongodb.Job, progress Progress) (string, error) {
+		logger.Log.Warn("jobs: no generator implemented yet for service type", zap.String("serviceType", serviceType))
+		return "", fmt.Errorf("%s: generator not implemented yet", serviceType)
+	}
+}
@@ -0,0 +1,145 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"go.uber.org/zap"
+)
+
+// Progress is how a Handler reports its own advancement back to the Job doc.
+type Progress func(pct int, msg string)
+
+// Handler runs one job of a given ServiceType and returns the URL of
+// whatever it produced. ctx carries the per-job timeout Worker derives from
+// its jobTimeout.
+type Handler func(ctx context.Context, job mongodb.Job, progress Progress) (resultURL string, err error)
+
+// Worker polls Queue with a fixed pool of goroutines and dispatches each
+// claimed job to the Handler registered for its ServiceType.
+type Worker struct {
+	queue       Queue
+	handlers    map[string]Handler
+	concurrency int
+	pollEvery   time.Duration
+	jobTimeout  time.Duration
+	leaseTTL    time.Duration
+
+	wg   sync.WaitGroup
+	stop chan struct{}
+}
+
+// NewWorker builds a Worker with concurrency goroutines, each claiming at
+// most one job at a time. jobTimeout bounds how long a single Handler call
+// may run; leaseTTL is how long a job may sit "running" before RequeueStale
+// treats it as orphaned by a crashed worker.
+func NewWorker(queue Queue, concurrency int, jobTimeout, leaseTTL time.Duration) *Worker {
+	return &Worker{
+		queue:       queue,
+		handlers:    make(map[string]Handler),
+		concurrency: concurrency,
+		pollEvery:   time.Second,
+		jobTimeout:  jobTimeout,
+		leaseTTL:    leaseTTL,
+		stop:        make(chan struct{}),
+	}
+}
+
+// Register binds a Handler to a ServiceType (e.g. "slide.generate"). It must
+// be called before Start.
+func (w *Worker) Register(serviceType string, handler Handler) {
+	w.handlers[serviceType] = handler
+}
+
+// Start requeues any jobs orphaned by a previous crash, then launches the
+// worker pool. It returns immediately; the pool runs until Shutdown.
+func (w *Worker) Start(ctx context.Context) {
+	if n, err := w.queue.RequeueStale(ctx, w.leaseTTL); err != nil {
+		logger.Log.Error("jobs: Start: failed to requeue stale jobs", zap.Error(err))
+	} else if n > 0 {
+		logger.Log.Info("jobs: Start: requeued orphaned jobs", zap.Int("count", n))
+	}
+
+	for i := 0; i < w.concurrency; i++ {
+		w.wg.Add(1)
+		go w.loop(ctx)
+	}
+}
+
+// Shutdown stops claiming new jobs and waits for in-flight ones to finish,
+// or for ctx to be done, whichever happens first.
+func (w *Worker) Shutdown(ctx context.Context) error {
+	close(w.stop)
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *Worker) loop(ctx context.Context) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.pollEvery)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.claimAndRun(ctx)
+		}
+	}
+}
+
+func (w *Worker) claimAndRun(ctx context.Context) {
+	job, err := w.queue.Claim(ctx)
+	if err != nil {
+		logger.Log.Error("jobs: claimAndRun: failed to claim job", zap.Error(err))
+		return
+	}
+	if job == nil {
+		return
+	}
+
+	handler, ok := w.handlers[job.ServiceType]
+	if !ok {
+		logger.Log.Error("jobs: claimAndRun: no handler registered", zap.String("serviceType", job.ServiceType))
+		_ = w.queue.Fail(ctx, job.JobID, "no handler registered for service type "+job.ServiceType)
+		return
+	}
+
+	jobCtx, cancel := context.WithTimeout(ctx, w.jobTimeout)
+	defer cancel()
+
+	progress := func(pct int, msg string) {
+		if err := w.queue.UpdateProgress(jobCtx, job.JobID, pct, msg); err != nil {
+			logger.Log.Error("jobs: progress: failed to persist progress", zap.String("jobId", job.JobID), zap.Error(err))
+		}
+	}
+
+	resultURL, err := handler(jobCtx, *job, progress)
+	if err != nil {
+		logger.Log.Error("jobs: claimAndRun: handler failed", zap.String("jobId", job.JobID), zap.Error(err))
+		_ = w.queue.Fail(ctx, job.JobID, err.Error())
+		return
+	}
+
+	if err := w.queue.Complete(ctx, job.JobID, resultURL); err != nil {
+		logger.Log.Error("jobs: claimAndRun: failed to mark job complete", zap.String("jobId", job.JobID), zap.Error(err))
+	}
+}
@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type ctxKey struct{}
+type traceIDKey struct{}
+
+// NewContext returns a copy of ctx carrying l as the request-scoped logger.
+func NewContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// NewTraceContext returns a copy of ctx carrying traceID, so it can be read
+// back later via TraceID — e.g. by ErrorHandler, which needs the same id
+// RequestLogger already stamped onto this request's logger, not a second
+// one of its own.
+func NewTraceContext(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, traceID)
+}
+
+// TraceID returns the trace_id stashed by middleware.RequestLogger, or ""
+// if ctx doesn't carry one.
+func TraceID(ctx context.Context) string {
+	id, _ := ctx.Value(traceIDKey{}).(string)
+	return id
+}
+
+// From returns the logger stashed in ctx by middleware.RequestLogger, or
+// the package-level Log if ctx doesn't carry one (e.g. a background job or
+// startup code with no originating HTTP request).
+func From(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(ctxKey{}).(*zap.Logger); ok && l != nil {
+		return l
+	}
+	return Log
+}
+
+// WithFields returns a context whose logger has the given fields attached,
+// building on whatever logger ctx already carries (or Log, if none).
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	return NewContext(ctx, From(ctx).With(fields...))
+}
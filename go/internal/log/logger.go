@@ -1,34 +1,53 @@
 package logger
 
 import (
+	"os"
 	"strings"
 
 	"go.uber.org/zap"
 )
 
+// Log is the base logger every package falls back to when there's no
+// request-scoped logger in context (startup, migrations, background
+// workers). Request-path code should prefer logger.From(ctx) instead, via
+// middleware.RequestLogger.
 var Log *zap.Logger
 
-// to init the global logger
+// LoadLogger builds Log for the given environment ("development"/"dev" or
+// "production"/"prod", defaulting to development for anything else).
+// Production additionally samples (100 initial entries per level per
+// second, then every 100th) so a noisy endpoint can't flood the sink.
+//
+// LOG_SINK overrides the encoding ("json" or "console") regardless of
+// environment. OTLP export isn't wired up here — it would mean swapping the
+// zapcore.Core for an OTLP exporter, which needs the go.opentelemetry.io
+// client libraries this module doesn't currently depend on — but the sink
+// is chosen through the same env var so adding it later doesn't touch
+// call sites.
 func LoadLogger(env string) {
-	var err error
-
 	normalized := strings.ToLower(strings.TrimSpace(env))
 
-	// checking the environment (development or production)
+	var cfg zap.Config
 	switch normalized {
 	case "development", "dev":
-		Log, err = zap.NewDevelopment()
+		cfg = zap.NewDevelopmentConfig()
 	case "production", "prod":
-		Log, err = zap.NewProduction()
+		cfg = zap.NewProductionConfig()
+		cfg.Sampling = &zap.SamplingConfig{Initial: 100, Thereafter: 100}
 	default:
-		// default to development if unknown or empty
-		Log, err = zap.NewDevelopment()
+		cfg = zap.NewDevelopmentConfig()
 		normalized = "development"
 	}
 
+	if sink := strings.ToLower(strings.TrimSpace(os.Getenv("LOG_SINK"))); sink != "" {
+		cfg.Encoding = sink
+	}
+
+	l, err := cfg.Build()
 	if err != nil {
 		panic("Failed to Initialize Logger: " + err.Error())
 	}
 
-	Log.Info("Logger Initialized ", zap.String("Environment", normalized))
+	Log = l
+	Log.Info("Logger Initialized ", zap.String("Environment", normalized), zap.String("encoding", cfg.Encoding))
 }
@@ -0,0 +1,96 @@
+// Package media handles image upload/serving on top of internal/storage:
+// POST /media dedupes by content hash before storing anything, and
+// GET /media/:hash negotiates a resized WebP variant instead of always
+// serving the (often much larger) original.
+package media
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/apierr"
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+const maxUploadBytes = 25 << 20 // 25MiB
+
+// Upload handles POST /media (authenticated, multipart/form-data with a
+// "file" field and an "altText" field): it hashes the upload, returns the
+// existing record immediately if that hash is already stored, and otherwise
+// persists the original and a new Media document.
+func Upload(repos *repo.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		altText := c.PostForm("altText")
+		if altText == "" {
+			c.Error(apierr.BadRequest("alt_text_required", "altText is required"))
+			return
+		}
+
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			c.Error(apierr.BadRequest("file_required", "file is required"))
+			return
+		}
+		if fileHeader.Size > maxUploadBytes {
+			c.Error(apierr.BadRequest("file_too_large", "file exceeds the 25MiB upload limit"))
+			return
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			c.Error(apierr.Internal("upload_failed", "internal server error").WithCause(err))
+			return
+		}
+		defer file.Close()
+
+		data, err := io.ReadAll(file)
+		if err != nil {
+			c.Error(apierr.Internal("upload_failed", "internal server error").WithCause(err))
+			return
+		}
+
+		hash := storage.Hash(data)
+
+		if existing, err := repos.Media.FindByHash(hash); err != nil {
+			c.Error(apierr.FromMongo(err))
+			return
+		} else if existing != nil {
+			c.JSON(http.StatusOK, existing)
+			return
+		}
+
+		width, height, err := storage.Decode(data)
+		if err != nil {
+			c.Error(apierr.BadRequest("invalid_image", "file is not a decodable image"))
+			return
+		}
+
+		if err := storage.Store.Put(c.Request.Context(), storage.Key(hash), data, fileHeader.Header.Get("Content-Type")); err != nil {
+			c.Error(apierr.Internal("upload_failed", "internal server error").WithCause(err))
+			return
+		}
+
+		userID, _ := c.MustGet("claims").(map[string]any)["ID"].(string)
+
+		record := mongodb.Media{
+			AltText:    altText,
+			Type:       "image",
+			UploadedBy: userID,
+			Hash:       hash,
+			Width:      width,
+			Height:     height,
+		}
+
+		id, err := repos.Media.Create(record)
+		if err != nil {
+			c.Error(apierr.FromMongo(err))
+			return
+		}
+		record.MediaID = id
+
+		c.JSON(http.StatusCreated, record)
+	}
+}
@@ -1,11 +1,14 @@
 package middleware
 
 import (
-	"net/http"
+	"errors"
 	"strings"
 
+	"github.com/SharveshRamchandani/aieduthon.git/internal/apierr"
 	"github.com/SharveshRamchandani/aieduthon.git/internal/handlers"
 	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/oidc"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/revocation"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"go.uber.org/zap"
@@ -25,42 +28,100 @@ func JWTMiddleWare() gin.HandlerFunc{
 		}
 
 		if TokenString == "" {
-            logger.Log.Debug("JWT token not found in request (user not authenticated)", 
-                zap.String("path", ctx.Request.URL.Path))
-            ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized access"})
+            logger.From(ctx.Request.Context()).Debug("JWT token not found in request (user not authenticated)")
+            ctx.Error(apierr.Unauthorized("unauthorized", "unauthorized access"))
+            ctx.Abort()
             return
         }
 
+		isHMAC := true
 		parse, err := jwt.Parse(TokenString, func(t *jwt.Token) (interface{}, error) {
             if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-                logger.Log.Error("JWT signing method mismatch", zap.String("method", t.Method.Alg()))
+                isHMAC = false
                 return nil, jwt.ErrSignatureInvalid
             }
             return handlers.JwtKey, nil
         })
 
+		if err != nil && errors.Is(err, jwt.ErrTokenExpired) {
+			// Surfaced distinctly (not just "unauthorized") so the frontend
+			// knows to call POST /auth/refresh instead of bouncing to login.
+			logger.From(ctx.Request.Context()).Debug("JWT access token expired")
+			ctx.Error(apierr.Unauthorized("token_expired", "access token expired"))
+			ctx.Abort()
+			return
+		}
+
+		// Not one of our own HMAC tokens — if an external OIDC provider is
+		// configured, it may be one of theirs (RS256/ES256) instead.
+		if !isHMAC && oidc.Enabled() {
+			oidcClaims, oidcErr := oidc.VerifyToken(TokenString, "")
+			if oidcErr != nil {
+				logger.From(ctx.Request.Context()).Error("OIDC token validation failed", zap.Error(oidcErr))
+				ctx.Error(apierr.Unauthorized("unauthorized", "unauthorized access"))
+				ctx.Abort()
+				return
+			}
+
+			ctx.Set("claims", oidcClaims.AsClaimsMap())
+			setAuthenticatedLogger(ctx, oidcClaims.Sub)
+			ctx.Next()
+			return
+		}
+
 		if err != nil || !parse.Valid {
-            logger.Log.Error("JWT token validation failed", zap.Error(err))
-            ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized access"})
+            logger.From(ctx.Request.Context()).Error("JWT token validation failed", zap.Error(err))
+            ctx.Error(apierr.Unauthorized("unauthorized", "unauthorized access"))
+            ctx.Abort()
             return
         }
 
 		claims, ok := parse.Claims.(jwt.MapClaims)
         if !ok {
-            logger.Log.Error("JWT claims extraction failed")
-            ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized access"})
+            logger.From(ctx.Request.Context()).Error("JWT claims extraction failed")
+            ctx.Error(apierr.Unauthorized("unauthorized", "unauthorized access"))
+            ctx.Abort()
             return
         }
 
-        ctx.Set("claims", mapFromClaims(claims))
+        mapped := mapFromClaims(claims)
+
+        if jti, _ := mapped["jti"].(string); jti != "" {
+            revoked, revokeErr := revocation.Default.IsRevoked(ctx.Request.Context(), jti)
+            if revokeErr != nil {
+                logger.From(ctx.Request.Context()).Error("Failed to check access token revocation", zap.Error(revokeErr))
+                ctx.Error(apierr.Internal("internal_error", "internal server error").WithCause(revokeErr))
+                ctx.Abort()
+                return
+            }
+            if revoked {
+                logger.From(ctx.Request.Context()).Debug("JWT access token has been revoked")
+                ctx.Error(apierr.Unauthorized("token_revoked", "access token has been revoked"))
+                ctx.Abort()
+                return
+            }
+        }
+
+        ctx.Set("claims", mapped)
+        if sub, _ := mapped["ID"].(string); sub != "" {
+            setAuthenticatedLogger(ctx, sub)
+        }
         ctx.Next()
 	}
 }
 
+// setAuthenticatedLogger enriches the request-scoped logger (set up by
+// RequestLogger) with the caller's sub once JWTMiddleWare has verified who
+// they are, so every log line for the rest of the request carries it.
+func setAuthenticatedLogger(ctx *gin.Context, sub string) {
+	enriched := logger.WithFields(ctx.Request.Context(), zap.String("sub", sub))
+	ctx.Request = ctx.Request.WithContext(enriched)
+}
+
 func mapFromClaims(c jwt.MapClaims) map[string]any{
 	out := make(map[string]any)
 	for k, v := range c{
 		out[k] = v
 	}
 	return  out
-}
\ No newline at end of file
+}
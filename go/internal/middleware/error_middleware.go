@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"github.com/SharveshRamchandani/aieduthon.git/internal/apierr"
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ErrorHandler renders every *apierr.Error pushed onto the Gin context via
+// c.Error into one canonical JSON body, so the frontend no longer has to
+// guess between "Error", "error", "message" and "Message". It should be
+// registered before any route so it wraps the whole chain.
+func ErrorHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		if len(ctx.Errors) == 0 {
+			return
+		}
+
+		// Reuse the trace_id RequestLogger already stamped on this request's
+		// logger, so error.requestId in the response can be grepped straight
+		// against that request's log lines instead of a second, unrelated id.
+		requestID := logger.TraceID(ctx.Request.Context())
+		if requestID == "" {
+			requestID = "unknown"
+		}
+
+		// The last pushed error wins — handlers are expected to c.Error + return,
+		// so there should only ever be one, but favour the most recent just in case.
+		ginErr := ctx.Errors.Last()
+
+		apiErr, ok := ginErr.Err.(*apierr.Error)
+		if !ok {
+			apiErr = apierr.Internal("internal_error", "internal server error").WithCause(ginErr.Err)
+		}
+
+		logger.From(ctx.Request.Context()).Error("request failed",
+			zap.String("requestId", requestID),
+			zap.String("code", apiErr.Code),
+			zap.Error(apiErr),
+		)
+
+		ctx.JSON(apiErr.Status, gin.H{
+			"error": gin.H{
+				"code":      apiErr.Code,
+				"message":   apiErr.Message,
+				"fields":    apiErr.Fields,
+				"requestId": requestID,
+			},
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/apierr"
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+	logger.Log = zap.NewNop()
+}
+
+type errorBody struct {
+	Error struct {
+		Code      string         `json:"code"`
+		Message   string         `json:"message"`
+		Fields    map[string]any `json:"fields"`
+		RequestID string         `json:"requestId"`
+	} `json:"error"`
+}
+
+func runErrorHandler(t *testing.T, handler gin.HandlerFunc) (*httptest.ResponseRecorder, errorBody) {
+	t.Helper()
+
+	r := gin.New()
+	r.Use(RequestLogger(), ErrorHandler())
+	r.GET("/test", handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	var body errorBody
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response body is not valid JSON: %v (body: %s)", err, rec.Body.String())
+	}
+	return rec, body
+}
+
+func TestErrorHandlerRendersApierrError(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    *apierr.Error
+		status int
+	}{
+		{"BadRequest", apierr.BadRequest("invalid_request", "request body could not be parsed"), http.StatusBadRequest},
+		{"Unauthorized", apierr.Unauthorized("unauthorized", "unauthorized access"), http.StatusUnauthorized},
+		{"NotFound", apierr.NotFound("not_found", "resource not found"), http.StatusNotFound},
+		{"Conflict", apierr.Conflict("already_exists", "resource already exists"), http.StatusConflict},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rec, body := runErrorHandler(t, func(c *gin.Context) {
+				c.Error(tc.err)
+			})
+
+			if rec.Code != tc.status {
+				t.Errorf("status = %d, want %d", rec.Code, tc.status)
+			}
+			if body.Error.Code != tc.err.Code {
+				t.Errorf("error.code = %q, want %q", body.Error.Code, tc.err.Code)
+			}
+			if body.Error.Message != tc.err.Message {
+				t.Errorf("error.message = %q, want %q", body.Error.Message, tc.err.Message)
+			}
+			if body.Error.RequestID == "" || body.Error.RequestID == "unknown" {
+				t.Errorf("error.requestId = %q, want a generated trace id", body.Error.RequestID)
+			}
+		})
+	}
+}
+
+func TestErrorHandlerWrapsNonApierrError(t *testing.T) {
+	rec, body := runErrorHandler(t, func(c *gin.Context) {
+		c.Error(errors.New("some unexpected failure"))
+	})
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if body.Error.Code != "internal_error" {
+		t.Errorf("error.code = %q, want %q", body.Error.Code, "internal_error")
+	}
+}
+
+func TestErrorHandlerRequestIDMatchesTraceID(t *testing.T) {
+	var seenTraceID string
+	r := gin.New()
+	r.Use(RequestLogger(), ErrorHandler())
+	r.GET("/test", func(c *gin.Context) {
+		seenTraceID = logger.TraceID(c.Request.Context())
+		c.Error(apierr.NotFound("not_found", "resource not found"))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	var body errorBody
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response body is not valid JSON: %v", err)
+	}
+
+	if body.Error.RequestID != seenTraceID {
+		t.Errorf("error.requestId = %q, want it to match the request's trace_id %q", body.Error.RequestID, seenTraceID)
+	}
+}
+
+func TestErrorHandlerNoErrorsLeavesResponseUntouched(t *testing.T) {
+	r := gin.New()
+	r.Use(RequestLogger(), ErrorHandler())
+	r.GET("/test", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"github.com/SharveshRamchandani/aieduthon.git/internal/apierr"
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/scope"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RequireRoles gates a route group on the caller's JWT "roles" claim,
+// granting access if the caller holds at least one of the given roles. It
+// must run after JWTMiddleWare so "claims" is already set on the context.
+func RequireRoles(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		claims, ok := ctx.MustGet("claims").(map[string]any)
+		if !ok || !hasAnyRole(claims, roles) {
+			auditDenied(ctx, "role", roles)
+			ctx.Error(apierr.Forbidden("insufficient_role", "you do not have permission to perform this action"))
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// RequireScopes gates a route group on the caller's JWT "scope" claim,
+// granting access only if the caller holds every given scope. It must run
+// after JWTMiddleWare so "claims" is already set on the context.
+func RequireScopes(scopes ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		claims, ok := ctx.MustGet("claims").(map[string]any)
+		if !ok || !hasAllScopes(claims, scopes) {
+			auditDenied(ctx, "scope", scopes)
+			ctx.Error(apierr.Forbidden("insufficient_scope", "you do not have permission to perform this action"))
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func hasAnyRole(claims map[string]any, required []string) bool {
+	held := claimStrings(claims["roles"])
+	for _, want := range required {
+		for _, have := range held {
+			if have == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasAllScopes(claims map[string]any, required []string) bool {
+	raw, _ := claims["scope"].(string)
+	return scope.Parse(raw).HasAll(required...)
+}
+
+// claimStrings normalizes a "roles" claim, which may come back as []string
+// (set by this app's own CreateJWTToken) or []any (after a round trip
+// through jwt.MapClaims, which decodes JSON arrays as []any).
+func claimStrings(v any) []string {
+	switch roles := v.(type) {
+	case []string:
+		return roles
+	case []any:
+		out := make([]string, 0, len(roles))
+		for _, r := range roles {
+			if s, ok := r.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// auditDenied logs an access-denial so admin/moderation endpoints have a
+// paper trail of who was turned away and why, without every handler having
+// to remember to log it itself.
+func auditDenied(ctx *gin.Context, kind string, required []string) {
+	claims, _ := ctx.MustGet("claims").(map[string]any)
+	sub, _ := claims["ID"].(string)
+	logger.From(ctx.Request.Context()).Warn("rbac: access denied",
+		zap.String("sub", sub),
+		zap.String("path", ctx.Request.URL.Path),
+		zap.String("kind", kind),
+		zap.Strings("required", required),
+	)
+}
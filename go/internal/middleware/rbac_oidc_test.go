@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/oidc"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/scope"
+	"github.com/gin-gonic/gin"
+)
+
+// These drive RequireScopes through claims shaped exactly like JWTMiddleWare's
+// OIDC branch produces via oidc.Claims.AsClaimsMap — guarding against the
+// regression where an OIDC-authenticated caller had no "scope" claim at all,
+// so scope.Parse("").HasAll(...) was always false and every scope-gated
+// route rejected them unconditionally.
+func TestRequireScopesWithOIDCClaims(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	setOIDCClaims := func(claims oidc.Claims) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Set("claims", claims.AsClaimsMap())
+			c.Next()
+		}
+	}
+
+	t.Run("caller with no scope claim still gets the default self-service scopes", func(t *testing.T) {
+		r := gin.New()
+		r.Use(setOIDCClaims(oidc.Claims{Sub: "oidc|teacher-1", Email: "teacher@example.com"}))
+		r.POST("/prompts", RequireScopes(scope.PromptsWrite), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/prompts", nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+	})
+
+	t.Run("caller still rejected for a scope outside the default set", func(t *testing.T) {
+		r := gin.New()
+		r.Use(setOIDCClaims(oidc.Claims{Sub: "oidc|teacher-1", Email: "teacher@example.com"}))
+		r.POST("/diagrams", RequireScopes(scope.DiagramsGenerate), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/diagrams", nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+		}
+	})
+}
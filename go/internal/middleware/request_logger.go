@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RequestLogger builds a per-request logger carrying a generated trace_id
+// plus method/path/user_agent, and stashes it on the request context so
+// every logger.From(ctx) call downstream is automatically correlated to
+// this request. It should be registered before JWTMiddleWare so the logger
+// already exists by the time JWTMiddleWare enriches it with the caller's
+// sub claim.
+func RequestLogger() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		traceID, err := newTraceID()
+		if err != nil {
+			traceID = "unknown"
+		}
+
+		requestLogger := logger.Log.With(
+			zap.String("trace_id", traceID),
+			zap.String("method", ctx.Request.Method),
+			zap.String("path", ctx.Request.URL.Path),
+			zap.String("user_agent", ctx.Request.UserAgent()),
+		)
+
+		reqCtx := logger.NewContext(ctx.Request.Context(), requestLogger)
+		reqCtx = logger.NewTraceContext(reqCtx, traceID)
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+		ctx.Next()
+	}
+}
+
+func newTraceID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/db"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// jobsCollectionSizeBytes bounds the capped jobs collection so a backlog of
+// finished jobs can't grow the DB unbounded; oldest documents roll off
+// automatically once the cap is hit.
+const jobsCollectionSizeBytes = 64 * 1024 * 1024
+
+// ensureJobsCollection creates the jobs collection capped, which is what
+// lets internal/jobs.Queue claim work with findOneAndUpdate without a
+// separate TTL/cleanup job for completed entries.
+func ensureJobsCollection(ctx context.Context) error {
+	opts := options.CreateCollection().SetCapped(true).SetSizeInBytes(jobsCollectionSizeBytes)
+
+	err := db.MongoDataBase.CreateCollection(ctx, "jobs", opts)
+	if err != nil && !alreadyexists(err) {
+		return err
+	}
+	return nil
+}
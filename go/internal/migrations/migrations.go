@@ -7,41 +7,113 @@ import (
 	"github.com/SharveshRamchandani/aieduthon.git/internal/db"
 	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
 	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
 	"go.uber.org/zap"
 )
 
-func RunMigrations(){
+func RunMigrations(container *repo.Container) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	if err := ensureJobsCollection(ctx); err != nil {
+		logger.Log.Error("failed to create jobs collection", zap.Error(err))
+		return
+	}
+	if container != nil {
+		if indexer, ok := container.Jobs.(repo.Indexer); ok {
+			if err := indexer.EnsureIndexes(ctx); err != nil {
+				logger.Log.Error("failed to create index to collection", zap.String("Collection: ", "jobs"), zap.Error(err))
+				return
+			}
+		}
+	}
+
 	modals := map[string]interface{}{
 		"users" : mongodb.Users{},
 		"analytics" : mongodb.Analytics{},
 		"diagrams" : mongodb.Diagram{},
-		"jobs" : mongodb.Job{},
 		"media" : mongodb.Media{},
 		"prompts" : mongodb.Prompt{},
+		"prompt_embeddings" : mongodb.PromptEmbedding{},
 		"quizzes" : mongodb.Quiz{},
 		"slides" : mongodb.Slide{},
 		"templates" : mongodb.Template{},
 		"translations" : mongodb.Translation{},
+		"refresh_tokens" : mongodb.RefreshToken{},
+		"revoked_access_tokens" : mongodb.RevokedAccessToken{},
+		"webauthn_credentials" : mongodb.Credential{},
 	}
 
+	// Collections backed by a repo own their own index setup via
+	// repo.Indexer; the generic reflect-based CreateIndexFeild below only
+	// runs for collections that don't have one yet.
+	indexers := map[string]repo.Indexer{}
+	if container != nil {
+		indexers = repoIndexers(container)
+	}
 
 	for collectionName, modal := range modals{
-		
+
 		err := db.MongoDataBase.CreateCollection(ctx, collectionName)
 		if err != nil && !alreadyexists(err){
 			logger.Log.Error("failed to create collection", zap.String("collectionName: ", collectionName), zap.Error(err))
 			return
 		}
 
+		if indexer, ok := indexers[collectionName]; ok && indexer != nil {
+			if err := indexer.EnsureIndexes(ctx); err != nil {
+				logger.Log.Error("failed to create index to collection", zap.String("Collection: ", collectionName), zap.Error(err))
+				return
+			}
+			continue
+		}
+
 		if err := CreateIndexFeild(ctx, db.MongoDataBase.Collection(collectionName), modal); err != nil {
 			logger.Log.Error("failed to create index to collection", zap.String("Collection: ", collectionName), zap.Error(err))
 			return
 		}
 	}
 
+	if err := ensureRefreshTokenIndexes(ctx); err != nil {
+		logger.Log.Error("failed to create refresh_tokens indexes", zap.Error(err))
+		return
+	}
+
+	if err := ensureRevokedAccessTokenIndexes(ctx); err != nil {
+		logger.Log.Error("failed to create revoked_access_tokens indexes", zap.Error(err))
+		return
+	}
+
 	logger.Log.Info("Migrations are complete, DB is ready")
 }
 
+func repoIndexers(container *repo.Container) map[string]repo.Indexer {
+	indexers := map[string]repo.Indexer{}
+
+	if indexer, ok := container.Users.(repo.Indexer); ok {
+		indexers["users"] = indexer
+	}
+	if indexer, ok := container.Slides.(repo.Indexer); ok {
+		indexers["slides"] = indexer
+	}
+	if indexer, ok := container.Quizzes.(repo.Indexer); ok {
+		indexers["quizzes"] = indexer
+	}
+	if indexer, ok := container.Templates.(repo.Indexer); ok {
+		indexers["templates"] = indexer
+	}
+	if indexer, ok := container.Analytics.(repo.Indexer); ok {
+		indexers["analytics"] = indexer
+	}
+	if indexer, ok := container.WebAuthn.(repo.Indexer); ok {
+		indexers["webauthn_credentials"] = indexer
+	}
+	if indexer, ok := container.Media.(repo.Indexer); ok {
+		indexers["media"] = indexer
+	}
+	if indexer, ok := container.Prompts.(repo.Indexer); ok {
+		indexers["prompts"] = indexer
+	}
+
+	return indexers
+}
@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ensureRefreshTokenIndexes sets up the compound {userId, family} index used
+// to revoke an entire refresh-token family in one update. The per-field
+// unique index on tokenHash is already covered by CreateIndexFeild.
+func ensureRefreshTokenIndexes(ctx context.Context) error {
+	collection := db.MongoDataBase.Collection("refresh_tokens")
+
+	index := mongo.IndexModel{
+		Keys: bson.D{{Key: "userId", Value: 1}, {Key: "family", Value: 1}},
+	}
+
+	_, err := collection.Indexes().CreateOne(ctx, index)
+	return err
+}
@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ensureRevokedAccessTokenIndexes sets up a TTL index on expiresAt so
+// revocation entries are garbage-collected once the token they refer to
+// would have expired anyway — the unique index on jti is already covered by
+// CreateIndexFeild.
+func ensureRevokedAccessTokenIndexes(ctx context.Context) error {
+	collection := db.MongoDataBase.Collection("revoked_access_tokens")
+
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+
+	_, err := collection.Indexes().CreateOne(ctx, index)
+	return err
+}
@@ -3,11 +3,16 @@ package mongodb
 import "time"
 
 type Job struct {
-    JobID       string    `bson:"_id,omitempty" json:"jobId"`
-    ServiceType string    `bson:"serviceType" json:"serviceType"`
-    Status      string    `bson:"status" json:"status"`
-    ResultURL   string    `bson:"resultUrl" json:"resultUrl"`
-    Error       string    `bson:"error,omitempty" json:"error"`
-    CreatedAt   time.Time `bson:"createdAt" json:"createdAt"`
-    CompletedAt time.Time `bson:"completedAt,omitempty" json:"completedAt"`
-}
\ No newline at end of file
+    JobID       string         `bson:"_id,omitempty" json:"jobId"`
+    UserID      string         `bson:"userId" json:"userId"`
+    ServiceType string         `bson:"serviceType" json:"serviceType"`
+    Status      string         `bson:"status" json:"status"`
+    Payload     map[string]any `bson:"payload,omitempty" json:"payload,omitempty"`
+    Progress    int            `bson:"progress" json:"progress"`
+    ProgressMsg string         `bson:"progressMsg,omitempty" json:"progressMsg,omitempty"`
+    ResultURL   string         `bson:"resultUrl" json:"resultUrl"`
+    Error       string         `bson:"error,omitempty" json:"error"`
+    CreatedAt   time.Time      `bson:"createdAt" json:"createdAt"`
+    ClaimedAt   time.Time      `bson:"claimedAt,omitempty" json:"claimedAt,omitempty"`
+    CompletedAt time.Time      `bson:"completedAt,omitempty" json:"completedAt"`
+}
@@ -13,4 +13,14 @@ type Media struct {
     GeneratedByAI bool     `bson:"generatedByAI" json:"generatedByAI"`
     UploadedBy   string    `bson:"uploadedBy" json:"uploadedBy"`
     CreatedAt    time.Time `bson:"createdAt" json:"createdAt"`
+
+    // Hash, Width/Height and Variants back the content-addressable storage
+    // pipeline (internal/storage): Hash is the SHA-256 of the original
+    // upload and doubles as its dedupe key and object-store path (media/<hash>).
+    // Variants maps "<width>.webp" -> its cache key, populated lazily as
+    // internal/media serves each size on first request.
+    Hash     string            `bson:"hash" json:"hash" unique:"true"`
+    Width    int               `bson:"width,omitempty" json:"width,omitempty"`
+    Height   int               `bson:"height,omitempty" json:"height,omitempty"`
+    Variants map[string]string `bson:"variants,omitempty" json:"variants,omitempty"`
 }
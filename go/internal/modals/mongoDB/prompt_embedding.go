@@ -0,0 +1,13 @@
+package mongodb
+
+// PromptEmbedding is the vector representation of one Prompt's PromptText,
+// kept in its own "prompt_embeddings" collection (accessed via the legacy
+// db/get+post pattern, like refresh_tokens) rather than repo.Container,
+// since it's derived data with no swappable-backend needs of its own.
+type PromptEmbedding struct {
+	ID       string    `bson:"_id,omitempty" json:"id"`
+	PromptID string    `bson:"promptId" json:"promptId" unique:"true"`
+	Vector   []float32 `bson:"vector" json:"-"`
+	Model    string    `bson:"model" json:"model"`
+	Dim      int       `bson:"dim" json:"dim"`
+}
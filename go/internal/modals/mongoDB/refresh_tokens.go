@@ -0,0 +1,19 @@
+package mongodb
+
+import "time"
+
+// RefreshToken is the server-side record for an opaque refresh token. Only the
+// SHA-256 hash of the token is ever persisted; the raw value is handed to the
+// client once and never stored.
+type RefreshToken struct {
+	ID         string    `bson:"_id,omitempty" json:"id"`
+	UserID     string    `bson:"userId" json:"userId"`
+	TokenHash  string    `bson:"tokenHash" json:"tokenHash" unique:"true"`
+	Family     string    `bson:"family" json:"family"`
+	IssuedAt   time.Time `bson:"issuedAt" json:"issuedAt"`
+	ExpiresAt  time.Time `bson:"expiresAt" json:"expiresAt"`
+	RevokedAt  time.Time `bson:"revokedAt,omitempty" json:"revokedAt,omitempty"`
+	ReplacedBy string    `bson:"replacedBy,omitempty" json:"replacedBy,omitempty"`
+	UserAgent  string    `bson:"userAgent,omitempty" json:"userAgent,omitempty"`
+	IP         string    `bson:"ip,omitempty" json:"ip,omitempty"`
+}
@@ -0,0 +1,16 @@
+package mongodb
+
+import "time"
+
+// RevokedAccessToken records a logged-out access token's jti so
+// JWTMiddleWare can reject it immediately instead of waiting for its own
+// (short) expiry. ExpiresAt mirrors the token's exp claim — a TTL index on
+// it lets Mongo garbage-collect entries once the token would have expired
+// anyway.
+type RevokedAccessToken struct {
+	ID        string    `bson:"_id,omitempty" json:"id"`
+	JTI       string    `bson:"jti" json:"jti" unique:"true"`
+	UserID    string    `bson:"userId" json:"userId"`
+	RevokedAt time.Time `bson:"revokedAt" json:"revokedAt"`
+	ExpiresAt time.Time `bson:"expiresAt" json:"expiresAt"`
+}
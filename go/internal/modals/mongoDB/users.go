@@ -9,8 +9,18 @@ type Users struct {
 
 	GoogleID string `bson:"google_id,omitempty" json:"google_id,omitempty"`
 
+	// ProviderIDs lets one account link multiple OAuth providers (e.g. Google
+	// + GitHub) by keeping each provider's own user ID, keyed by provider name.
+	ProviderIDs map[string]string `bson:"provider_ids,omitempty" json:"provider_ids,omitempty"`
+
 	AuthProvider string `bson:"auth_provider" json:"auth_provider"`
 	Organisation string `bson:"orgaanisation" json:"organisation"`
 	LastLogin    string `bson:"lastlogin" json:"lastlogin"`
 	Createdat    string `bson:"createdat" json:"created_at"`
+
+	// Roles and Scopes are embedded into every JWT this user is issued and
+	// checked by middleware.RequireRoles/RequireScopes. Roles should be drawn
+	// from rbac's canonical set; Scopes from scope's.
+	Roles  []string `bson:"roles,omitempty" json:"roles,omitempty"`
+	Scopes []string `bson:"scopes,omitempty" json:"scopes,omitempty"`
 }
@@ -0,0 +1,19 @@
+package mongodb
+
+import "time"
+
+// Credential is a single registered WebAuthn authenticator (a passkey) for
+// a user. CredentialID is the authenticator's own identifier, not our
+// document _id, and is what login/begin uses to populate allowCredentials.
+type Credential struct {
+	ID           string    `bson:"_id,omitempty" json:"id"`
+	UserID       string    `bson:"userId" json:"userId"`
+	CredentialID string    `bson:"credentialId" json:"credentialId" unique:"true"`
+	PublicKey    []byte    `bson:"publicKey" json:"-"`
+	SignCount    uint32    `bson:"signCount" json:"signCount"`
+	Transports   []string  `bson:"transports,omitempty" json:"transports,omitempty"`
+	AAGUID       []byte    `bson:"aaguid,omitempty" json:"-"`
+	CreatedAt    time.Time `bson:"createdAt" json:"createdAt"`
+	LastUsedAt   time.Time `bson:"lastUsedAt,omitempty" json:"lastUsedAt,omitempty"`
+	Nickname     string    `bson:"nickname,omitempty" json:"nickname,omitempty"`
+}
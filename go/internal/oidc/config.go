@@ -0,0 +1,65 @@
+// Package oidc lets the app accept ID/access tokens issued by an external
+// OIDC provider (e.g. an institutional SSO) in addition to the app's own
+// HMAC JWTs: it discovers the provider's endpoints, caches its JWKS, and
+// exposes gin handlers for the authorization-code+PKCE login dance plus
+// RP-initiated logout. This is separate from internal/Auth, which is the
+// goth-based "log in with Google/GitHub" social flow — oidc is for
+// providers the app trusts as a resource server, not just a login button.
+package oidc
+
+import (
+	"os"
+	"strings"
+)
+
+// Config is built once by Setup from env and used by every handler/verifier
+// in this package.
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// instance is the package-level Config built by Setup, mirroring
+// webauthn.WebAuthn's package-level-instance convention.
+var instance *Config
+
+// Setup loads OIDC_ISSUER, OIDC_CLIENT_ID, OIDC_CLIENT_SECRET,
+// OIDC_REDIRECT_URL and OIDC_SCOPES (space-separated, defaulting to
+// "openid email profile") from the environment and fetches the provider's
+// discovery document. An unset OIDC_ISSUER is not an error — it just means
+// no external provider is configured and the JWT middleware only accepts
+// the app's own HMAC tokens.
+func Setup() error {
+	issuer := os.Getenv("OIDC_ISSUER")
+	if issuer == "" {
+		return nil
+	}
+
+	scopes := strings.Fields(os.Getenv("OIDC_SCOPES"))
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+
+	cfg := &Config{
+		Issuer:       issuer,
+		ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
+		Scopes:       scopes,
+	}
+
+	if err := loadDiscovery(cfg.Issuer); err != nil {
+		return err
+	}
+
+	instance = cfg
+	return nil
+}
+
+// Enabled reports whether Setup found an OIDC_ISSUER to configure against.
+func Enabled() bool {
+	return instance != nil
+}
@@ -0,0 +1,98 @@
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	"go.uber.org/zap"
+)
+
+// discoveryRefreshInterval governs how often the JWKS is re-fetched in the
+// background so a provider's key rotation is picked up without a restart.
+const discoveryRefreshInterval = 1 * time.Hour
+
+// document is the subset of the provider's
+// .well-known/openid-configuration this package actually uses.
+type document struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	EndSessionEndpoint    string `json:"end_session_endpoint"`
+}
+
+var (
+	discoveryMu  sync.RWMutex
+	discoveryDoc document
+	keySet       jwks
+)
+
+// loadDiscovery fetches the discovery document and the JWKS it points to,
+// then starts a background refresh loop so key rotation doesn't require a
+// restart.
+func loadDiscovery(issuer string) error {
+	doc, err := fetchDiscovery(issuer)
+	if err != nil {
+		return err
+	}
+
+	keys, err := fetchJWKS(doc.JWKSURI)
+	if err != nil {
+		return err
+	}
+
+	discoveryMu.Lock()
+	discoveryDoc = doc
+	keySet = keys
+	discoveryMu.Unlock()
+
+	go refreshLoop(doc.JWKSURI)
+	return nil
+}
+
+func refreshLoop(jwksURI string) {
+	ticker := time.NewTicker(discoveryRefreshInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		keys, err := fetchJWKS(jwksURI)
+		if err != nil {
+			logger.Log.Error("oidc: refreshLoop: failed to refresh JWKS", zap.Error(err))
+			continue
+		}
+
+		discoveryMu.Lock()
+		keySet = keys
+		discoveryMu.Unlock()
+	}
+}
+
+func fetchDiscovery(issuer string) (document, error) {
+	url := issuer + "/.well-known/openid-configuration"
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return document{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return document{}, fmt.Errorf("oidc: discovery document request to %s returned %d", url, resp.StatusCode)
+	}
+
+	var doc document
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return document{}, err
+	}
+	return doc, nil
+}
+
+func currentDoc() (document, bool) {
+	discoveryMu.RLock()
+	defer discoveryMu.RUnlock()
+	return discoveryDoc, discoveryDoc.Issuer != ""
+}
@@ -0,0 +1,236 @@
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/apierr"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/handlers"
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/rbac"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/scope"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const providerName = "oidc"
+
+// Login handles GET /auth/oidc/login: it builds the authorization-code+PKCE
+// redirect, stashing state/nonce/verifier in the session for Callback to
+// check against.
+func Login(c *gin.Context) {
+	if !Enabled() {
+		c.Error(apierr.Internal("oidc_not_configured", "internal server error"))
+		return
+	}
+
+	doc, _ := currentDoc()
+
+	state, err := newRandomString()
+	if err != nil {
+		c.Error(apierr.Internal("oidc_login_failed", "internal server error").WithCause(err))
+		return
+	}
+	nonce, err := newRandomString()
+	if err != nil {
+		c.Error(apierr.Internal("oidc_login_failed", "internal server error").WithCause(err))
+		return
+	}
+	verifier, challenge, err := newPKCE()
+	if err != nil {
+		c.Error(apierr.Internal("oidc_login_failed", "internal server error").WithCause(err))
+		return
+	}
+
+	if err := saveLoginSession(c, loginSession{State: state, Nonce: nonce, Verifier: verifier}); err != nil {
+		c.Error(apierr.Internal("oidc_login_failed", "internal server error").WithCause(err))
+		return
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {instance.ClientID},
+		"redirect_uri":          {instance.RedirectURL},
+		"scope":                 {strings.Join(instance.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	c.Redirect(http.StatusFound, doc.AuthorizationEndpoint+"?"+query.Encode())
+}
+
+// Callback handles GET /auth/oidc/callback: it exchanges the authorization
+// code for tokens, verifies the ID token, upserts the user, and issues the
+// app's own JWT+refresh pair exactly like the goth-based OAuthCallback does.
+func Callback(repos *repo.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !Enabled() {
+			c.Error(apierr.Internal("oidc_not_configured", "internal server error"))
+			return
+		}
+
+		loginSess, err := loadLoginSession(c)
+		if err != nil {
+			c.Error(apierr.Unauthorized("oidc_session_missing", "login session expired or missing"))
+			return
+		}
+
+		if c.Query("state") != loginSess.State {
+			c.Error(apierr.Unauthorized("oidc_state_mismatch", "state parameter mismatch"))
+			return
+		}
+
+		code := c.Query("code")
+		if code == "" {
+			c.Error(apierr.BadRequest("oidc_code_missing", "authorization code is required"))
+			return
+		}
+
+		tokens, err := exchangeCode(code, loginSess.Verifier)
+		if err != nil {
+			logger.From(c.Request.Context()).Error("oidc: Callback: code exchange failed", zap.Error(err))
+			c.Error(apierr.Internal("oidc_exchange_failed", "internal server error").WithCause(err))
+			return
+		}
+
+		claims, err := VerifyToken(tokens.IDToken, loginSess.Nonce)
+		if err != nil {
+			logger.From(c.Request.Context()).Error("oidc: Callback: id_token verification failed", zap.Error(err))
+			c.Error(apierr.Unauthorized("oidc_token_invalid", "could not verify identity token"))
+			return
+		}
+		clearLoginSession(c)
+
+		user, err := upsertOIDCUser(repos, *claims)
+		if err != nil {
+			c.Error(apierr.FromMongo(err))
+			return
+		}
+
+		JwtToken, err := handlers.CreateJWTToken(map[string]any{
+			"name":  user.UserName,
+			"ID":    user.ID,
+			"email": user.Email,
+			"roles": user.Roles,
+			"scope": scope.Join(user.Scopes),
+		})
+		if err != nil {
+			c.Error(apierr.Internal("token_creation_failed", "internal server error").WithCause(err))
+			return
+		}
+
+		refreshToken, err := handlers.IssueRefreshToken(c.Request.Context(), user.ID, c.Request.UserAgent(), c.ClientIP())
+		if err != nil {
+			c.Error(apierr.Internal("token_creation_failed", "internal server error").WithCause(err))
+			return
+		}
+
+		JwtExp := time.Now().Add(handlers.AccessTokenTTL).Unix()
+		c.SetCookie("jwt", JwtToken, int(JwtExp), "/", "localhost", false, true)
+		c.SetCookie("refresh_token", refreshToken, int(handlers.RefreshTokenTTL.Seconds()), "/", "localhost", false, true)
+
+		c.JSON(http.StatusOK, gin.H{"message": "logged in via institutional SSO"})
+	}
+}
+
+// Logout handles GET /auth/oidc/logout: an RP-initiated logout that sends
+// the user to the provider's end_session_endpoint so their provider-side
+// session ends too, not just this app's.
+func Logout(c *gin.Context) {
+	if !Enabled() {
+		c.Error(apierr.Internal("oidc_not_configured", "internal server error"))
+		return
+	}
+
+	doc, _ := currentDoc()
+	if doc.EndSessionEndpoint == "" {
+		c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+		return
+	}
+
+	redirect := c.Query("redirect_uri")
+	if redirect == "" {
+		redirect = instance.RedirectURL
+	}
+
+	query := url.Values{"client_id": {instance.ClientID}, "post_logout_redirect_uri": {redirect}}
+	c.Redirect(http.StatusFound, doc.EndSessionEndpoint+"?"+query.Encode())
+}
+
+type tokenResponse struct {
+	IDToken     string `json:"id_token"`
+	AccessToken string `json:"access_token"`
+}
+
+func exchangeCode(code, verifier string) (*tokenResponse, error) {
+	doc, _ := currentDoc()
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {instance.RedirectURL},
+		"client_id":     {instance.ClientID},
+		"client_secret": {instance.ClientSecret},
+		"code_verifier": {verifier},
+	}
+
+	resp, err := http.PostForm(doc.TokenEndpoint, form)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc: token endpoint returned %d", resp.StatusCode)
+	}
+
+	var tokens tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return nil, err
+	}
+	if tokens.IDToken == "" {
+		return nil, fmt.Errorf("oidc: token response did not include an id_token")
+	}
+	return &tokens, nil
+}
+
+// upsertOIDCUser finds the user by email only, linking the OIDC sub to that
+// account if it isn't linked yet, and creates one if no account has that
+// email. Users are not looked up by provider sub.
+func upsertOIDCUser(repos *repo.Container, claims Claims) (*mongodb.Users, error) {
+	if existing, err := repos.Users.FindByEmail(claims.Email); err != nil {
+		return nil, err
+	} else if existing != nil {
+		if _, linked := existing.ProviderIDs[providerName]; !linked {
+			if err := repos.Users.LinkProvider(existing.ID, providerName, claims.Sub); err != nil {
+				return nil, err
+			}
+		}
+		return existing, nil
+	}
+
+	u := mongodb.Users{
+		UserName:     claims.Email,
+		Email:        claims.Email,
+		AuthProvider: providerName,
+		ProviderIDs:  map[string]string{providerName: claims.Sub},
+		LastLogin:    time.Now().Format("Monday, 02-Jan-06 15:04:05 MST"),
+		Createdat:    time.DateOnly,
+		Roles:        rbac.DefaultRoles,
+		Scopes:       scope.DefaultScopes,
+	}
+	id, err := repos.Users.Create(u)
+	if err != nil {
+		return nil, err
+	}
+	u.ID = id
+	return &u, nil
+}
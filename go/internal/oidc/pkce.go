@@ -0,0 +1,34 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// newPKCE generates a PKCE code verifier and its S256 challenge, per RFC
+// 7636. The verifier is stashed in the session by /login and sent back to
+// the token endpoint by /callback; the challenge is the only part the
+// authorization endpoint sees.
+func newPKCE() (verifier, challenge string, err error) {
+	buf := make([]byte, 32)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	verifier = base64.RawURLEncoding.EncodeToString(buf)
+
+	sum := sha256.Sum256([]byte(verifier))
+	challenge = base64.RawURLEncoding.EncodeToString(sum[:])
+	return verifier, challenge, nil
+}
+
+// newRandomString is used for the state and nonce parameters, which only
+// need to be unguessable, not derived from anything.
+func newRandomString() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
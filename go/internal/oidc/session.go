@@ -0,0 +1,53 @@
+package oidc
+
+import (
+	"fmt"
+
+	auth "github.com/SharveshRamchandani/aieduthon.git/internal/Auth"
+	"github.com/gin-gonic/gin"
+)
+
+// loginSession is what /login stashes between the redirect and /callback:
+// the state+nonce to check for CSRF/replay, and the PKCE verifier the token
+// endpoint needs.
+type loginSession struct {
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+const (
+	sessionStateKey    = "oidc_state"
+	sessionNonceKey    = "oidc_nonce"
+	sessionVerifierKey = "oidc_verifier"
+)
+
+func saveLoginSession(c *gin.Context, s loginSession) error {
+	session, _ := auth.Store.Get(c.Request, "session")
+	session.Values[sessionStateKey] = s.State
+	session.Values[sessionNonceKey] = s.Nonce
+	session.Values[sessionVerifierKey] = s.Verifier
+	return session.Save(c.Request, c.Writer)
+}
+
+func loadLoginSession(c *gin.Context) (loginSession, error) {
+	session, _ := auth.Store.Get(c.Request, "session")
+
+	state, _ := session.Values[sessionStateKey].(string)
+	nonce, _ := session.Values[sessionNonceKey].(string)
+	verifier, _ := session.Values[sessionVerifierKey].(string)
+
+	if state == "" || verifier == "" {
+		return loginSession{}, fmt.Errorf("oidc: no login in progress")
+	}
+
+	return loginSession{State: state, Nonce: nonce, Verifier: verifier}, nil
+}
+
+func clearLoginSession(c *gin.Context) {
+	session, _ := auth.Store.Get(c.Request, "session")
+	delete(session.Values, sessionStateKey)
+	delete(session.Values, sessionNonceKey)
+	delete(session.Values, sessionVerifierKey)
+	_ = session.Save(c.Request, c.Writer)
+}
@@ -0,0 +1,146 @@
+package oidc
+
+import (
+	"fmt"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/scope"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims is the normalized shape the JWT middleware sets on the Gin context
+// for an OIDC-verified token, regardless of which provider issued it.
+type Claims struct {
+	Sub    string   `json:"sub"`
+	Email  string   `json:"email"`
+	Roles  []string `json:"roles,omitempty"`
+	Scope  string   `json:"scope,omitempty"`
+	Locale string   `json:"locale,omitempty"`
+	Nonce  string   `json:"nonce,omitempty"`
+}
+
+// VerifyToken parses tokenString as an RS256/ES256 JWT, looks its signing
+// key up by kid in the cached JWKS, and validates iss/aud/exp. wantNonce is
+// checked against the token's nonce claim when non-empty (the callback flow
+// passes the nonce it stashed at /login; callers that don't care, such as a
+// bearer token presented straight to the API, pass "").
+func VerifyToken(tokenString, wantNonce string) (*Claims, error) {
+	if !Enabled() {
+		return nil, fmt.Errorf("oidc: no provider configured")
+	}
+
+	doc, ok := currentDoc()
+	if !ok {
+		return nil, fmt.Errorf("oidc: provider discovery not loaded")
+	}
+
+	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (any, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		default:
+			return nil, fmt.Errorf("oidc: unsupported signing method %q", t.Method.Alg())
+		}
+
+		kid, _ := t.Header["kid"].(string)
+		key, ok := keyForKid(kid)
+		if !ok {
+			return nil, fmt.Errorf("oidc: unknown key id %q", kid)
+		}
+		return key, nil
+	},
+		jwt.WithIssuer(doc.Issuer),
+		jwt.WithAudience(instance.ClientID),
+	)
+	if err != nil || !token.Valid {
+		return nil, fmt.Errorf("oidc: token validation failed: %w", err)
+	}
+
+	mapClaims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, fmt.Errorf("oidc: could not read claims")
+	}
+
+	claims := &Claims{
+		Sub:    stringClaim(mapClaims, "sub"),
+		Email:  stringClaim(mapClaims, "email"),
+		Locale: stringClaim(mapClaims, "locale"),
+		Nonce:  stringClaim(mapClaims, "nonce"),
+		Roles:  rolesClaim(mapClaims),
+		Scope:  scopeClaim(mapClaims),
+	}
+
+	if wantNonce != "" && claims.Nonce != wantNonce {
+		return nil, fmt.Errorf("oidc: nonce mismatch")
+	}
+
+	return claims, nil
+}
+
+func stringClaim(claims jwt.MapClaims, key string) string {
+	v, _ := claims[key].(string)
+	return v
+}
+
+// rolesClaim accepts either a JSON array of strings or a single string under
+// the "roles" claim — providers disagree on which.
+func rolesClaim(claims jwt.MapClaims) []string {
+	switch v := claims["roles"].(type) {
+	case []any:
+		roles := make([]string, 0, len(v))
+		for _, r := range v {
+			if s, ok := r.(string); ok {
+				roles = append(roles, s)
+			}
+		}
+		return roles
+	case string:
+		return []string{v}
+	default:
+		return nil
+	}
+}
+
+// AsClaimsMap normalizes Claims into the map[string]any shape the JWT
+// middleware already sets on the Gin context for local HMAC tokens, so
+// downstream handlers can read c.MustGet("claims") the same way either way.
+// An empty Scope is reported as scope.DefaultScopes, matching scopeClaim.
+func (c Claims) AsClaimsMap() map[string]any {
+	scopeValue := c.Scope
+	if scopeValue == "" {
+		scopeValue = scope.Join(scope.DefaultScopes)
+	}
+	return map[string]any{
+		"ID":     c.Sub,
+		"sub":    c.Sub,
+		"email":  c.Email,
+		"roles":  c.Roles,
+		"scope":  scopeValue,
+		"locale": c.Locale,
+	}
+}
+
+// scopeClaim reads the token's own "scope"/"scp" claim (space-delimited
+// string or array, providers disagree on which) when the IdP issues one. If
+// it doesn't, middleware.RequireScopes would reject every OIDC user
+// unconditionally, so fall back to scope.DefaultScopes — the same baseline a
+// locally signed-up account gets — rather than leaving them scope-less.
+func scopeClaim(claims jwt.MapClaims) string {
+	for _, key := range []string{"scope", "scp"} {
+		switch v := claims[key].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case []any:
+			scopes := make([]string, 0, len(v))
+			for _, s := range v {
+				if str, ok := s.(string); ok {
+					scopes = append(scopes, str)
+				}
+			}
+			if len(scopes) > 0 {
+				return scope.Join(scopes)
+			}
+		}
+	}
+	return scope.Join(scope.DefaultScopes)
+}
@@ -0,0 +1,49 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/scope"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestScopeClaimPrefersTheTokensOwnScope(t *testing.T) {
+	cases := []struct {
+		name   string
+		claims jwt.MapClaims
+		want   string
+	}{
+		{
+			name:   "space-delimited string claim",
+			claims: jwt.MapClaims{"scope": "prompts:write diagrams:generate"},
+			want:   "prompts:write diagrams:generate",
+		},
+		{
+			name:   "scp array claim",
+			claims: jwt.MapClaims{"scp": []any{"prompts:write", "diagrams:generate"}},
+			want:   "prompts:write diagrams:generate",
+		},
+		{
+			name:   "no scope claim falls back to the default self-service scopes",
+			claims: jwt.MapClaims{},
+			want:   scope.Join(scope.DefaultScopes),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := scopeClaim(tc.claims); got != tc.want {
+				t.Errorf("scopeClaim() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAsClaimsMapIncludesScope(t *testing.T) {
+	claims := Claims{Sub: "oidc|123", Scope: scope.Join(scope.DefaultScopes)}
+
+	m := claims.AsClaimsMap()
+	if m["scope"] != scope.Join(scope.DefaultScopes) {
+		t.Errorf(`AsClaimsMap()["scope"] = %v, want %q`, m["scope"], scope.Join(scope.DefaultScopes))
+	}
+}
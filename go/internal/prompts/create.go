@@ -0,0 +1,98 @@
+package prompts
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/apierr"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/db/post"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/embed"
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type createRequest struct {
+	PromptText string `json:"promptText" binding:"required"`
+	Subject    string `json:"subject"`
+	GradeLevel string `json:"gradeLevel"`
+	Context    string `json:"context"`
+	Locale     string `json:"locale"`
+}
+
+// Create handles POST /api/prompts: it saves the prompt, then best-effort
+// embeds it for semantic reuse search — a failed embed call doesn't fail the
+// save. A prompt whose embed call failed has no stored embedding, and the
+// re-embed job (ReembedHandler) does not pick it up.
+func Create(repos *repo.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body createRequest
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.Error(apierr.FromBind(err))
+			return
+		}
+
+		claims := c.MustGet("claims").(map[string]any)
+		userID, _ := claims["ID"].(string)
+
+		promptID, err := newPromptID()
+		if err != nil {
+			c.Error(apierr.Internal("internal_error", "internal server error").WithCause(err))
+			return
+		}
+
+		prompt := mongodb.Prompt{
+			PromptID:   promptID,
+			UserID:     userID,
+			PromptText: body.PromptText,
+			Subject:    body.Subject,
+			GradeLevel: body.GradeLevel,
+			Context:    body.Context,
+			Locale:     body.Locale,
+			Timestamp:  time.Now(),
+		}
+
+		if _, err := repos.Prompts.Create(prompt); err != nil {
+			c.Error(apierr.FromMongo(err))
+			return
+		}
+
+		embedAndSave(c, prompt)
+
+		c.JSON(http.StatusCreated, prompt)
+	}
+}
+
+func embedAndSave(c *gin.Context, prompt mongodb.Prompt) {
+	if embed.Default == nil {
+		return
+	}
+
+	vector, err := embed.Default.Embed(c.Request.Context(), prompt.PromptText)
+	if err != nil {
+		logger.From(c.Request.Context()).Error("prompts: failed to embed prompt", zap.Error(err), zap.String("promptId", prompt.PromptID))
+		return
+	}
+
+	embedding := mongodb.PromptEmbedding{
+		PromptID: prompt.PromptID,
+		Vector:   vector,
+		Model:    embed.Default.Model(),
+		Dim:      len(vector),
+	}
+	if err := post.SavePromptEmbedding(embedding); err != nil {
+		logger.From(c.Request.Context()).Error("prompts: failed to save prompt embedding", zap.Error(err), zap.String("promptId", prompt.PromptID))
+	}
+}
+
+func newPromptID() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,70 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/db/get"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/db/post"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/embed"
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"go.uber.org/zap"
+)
+
+// ReembedHandler returns the "prompt.reembed" job handler, shaped to match
+// jobs.Handler without importing internal/jobs (which imports this package
+// to register the handler). It re-embeds every prompt whose stored embedding
+// was made with a model other than the currently configured one. Prompts
+// that never got an embedding at all (e.g. because Create's best-effort
+// embed call failed) have no stored embedding to find and are not re-embedded.
+// It's meant to be enqueued periodically, or by hand after switching
+// EMBEDDINGS_PROVIDER/model.
+func ReembedHandler(repos *repo.Container) func(ctx context.Context, job mongodb.Job, progress func(pct int, msg string)) (string, error) {
+	return func(ctx context.Context, job mongodb.Job, progress func(pct int, msg string)) (string, error) {
+		if embed.Default == nil {
+			return "", fmt.Errorf("prompt.reembed: no embedder configured")
+		}
+
+		outdated, err := get.ListOutdatedPromptEmbeddings(embed.Default.Model())
+		if err != nil {
+			return "", err
+		}
+
+		total := len(outdated)
+		reembedded := 0
+		for i, stale := range outdated {
+			prompt, err := repos.Prompts.FindByID(stale.PromptID)
+			if err != nil {
+				logger.Log.Error("prompts: reembed: failed to look up prompt", zap.String("promptId", stale.PromptID), zap.Error(err))
+				continue
+			}
+			if prompt == nil {
+				continue
+			}
+
+			vector, err := embed.Default.Embed(ctx, prompt.PromptText)
+			if err != nil {
+				logger.Log.Error("prompts: reembed: failed to embed prompt", zap.String("promptId", prompt.PromptID), zap.Error(err))
+				continue
+			}
+
+			embedding := mongodb.PromptEmbedding{
+				PromptID: prompt.PromptID,
+				Vector:   vector,
+				Model:    embed.Default.Model(),
+				Dim:      len(vector),
+			}
+			if err := post.SavePromptEmbedding(embedding); err != nil {
+				logger.Log.Error("prompts: reembed: failed to save embedding", zap.String("promptId", prompt.PromptID), zap.Error(err))
+				continue
+			}
+
+			reembedded++
+			progress(int(float64(i+1)/float64(total)*100), fmt.Sprintf("re-embedded %d/%d prompts", reembedded, total))
+		}
+
+		return fmt.Sprintf("re-embedded %d/%d prompts", reembedded, total), nil
+	}
+}
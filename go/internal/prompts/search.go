@@ -0,0 +1,91 @@
+package prompts
+
+import (
+	"net/http"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/apierr"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/embed"
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const defaultSearchLimit = 20
+
+// Search handles GET /api/prompts/search?q=&subject=&locale=: it merges
+// BM25 full-text hits (repo.PromptRepo.SearchText) with semantic k-NN hits
+// over prompt_embeddings, text hits first since they're an exact match on
+// the query terms. A missing or unhealthy embedder just means the semantic
+// half is skipped — text search alone is still a useful result.
+func Search(repos *repo.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := c.Query("q")
+		if query == "" {
+			c.Error(apierr.BadRequest("query_required", "q is required"))
+			return
+		}
+		subject := c.Query("subject")
+		locale := c.Query("locale")
+
+		textHits, err := repos.Prompts.SearchText(c.Request.Context(), query, subject, locale, defaultSearchLimit)
+		if err != nil {
+			c.Error(apierr.FromMongo(err))
+			return
+		}
+
+		semanticHits := semanticSearch(c, repos, query)
+
+		c.JSON(http.StatusOK, gin.H{"results": mergePrompts(textHits, semanticHits)})
+	}
+}
+
+// semanticSearch embeds query and resolves its nearest neighbours in
+// prompt_embeddings back to full Prompt documents. Any failure along the
+// way (no embedder configured, embed call fails, vector search fails) just
+// yields no semantic hits rather than failing the whole request.
+func semanticSearch(c *gin.Context, repos *repo.Container, query string) []mongodb.Prompt {
+	if embed.Default == nil {
+		return nil
+	}
+
+	vector, err := embed.Default.Embed(c.Request.Context(), query)
+	if err != nil {
+		logger.From(c.Request.Context()).Error("prompts: failed to embed search query", zap.Error(err))
+		return nil
+	}
+
+	ids, err := vectorNeighbors(c.Request.Context(), vector, defaultSearchLimit, "")
+	if err != nil {
+		logger.From(c.Request.Context()).Error("prompts: vector search failed", zap.Error(err))
+		return nil
+	}
+
+	prompts, err := repos.Prompts.FindByIDs(ids)
+	if err != nil {
+		logger.From(c.Request.Context()).Error("prompts: failed to resolve vector search hits", zap.Error(err))
+		return nil
+	}
+	return prompts
+}
+
+// mergePrompts returns text hits followed by any semantic hits not already
+// present, deduped by PromptID.
+func mergePrompts(textHits, semanticHits []mongodb.Prompt) []mongodb.Prompt {
+	seen := make(map[string]bool, len(textHits))
+	merged := make([]mongodb.Prompt, 0, len(textHits)+len(semanticHits))
+
+	for _, p := range textHits {
+		seen[p.PromptID] = true
+		merged = append(merged, p)
+	}
+	for _, p := range semanticHits {
+		if seen[p.PromptID] {
+			continue
+		}
+		seen[p.PromptID] = true
+		merged = append(merged, p)
+	}
+	return merged
+}
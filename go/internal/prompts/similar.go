@@ -0,0 +1,56 @@
+package prompts
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/apierr"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/db/get"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"github.com/gin-gonic/gin"
+)
+
+const defaultSimilarLimit = 5
+
+// Similar handles GET /api/prompts/:id/similar?limit=: it looks up the
+// prompt's stored embedding and returns its nearest neighbours, excluding
+// itself. Prompts saved before embeddings existed, or while the embedder
+// was down, have no stored vector — that's a 404, not a 500. The re-embed
+// job only refreshes embeddings that already exist, so it does not fill
+// these in.
+func Similar(repos *repo.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		promptID := c.Param("id")
+
+		limit := defaultSimilarLimit
+		if raw := c.Query("limit"); raw != "" {
+			if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+				limit = parsed
+			}
+		}
+
+		embedding, err := get.FindPromptEmbedding(promptID)
+		if err != nil {
+			c.Error(apierr.Internal("internal_error", "internal server error").WithCause(err))
+			return
+		}
+		if embedding == nil {
+			c.Error(apierr.NotFound("embedding_not_found", "no embedding stored for this prompt yet"))
+			return
+		}
+
+		ids, err := vectorNeighbors(c.Request.Context(), embedding.Vector, limit, promptID)
+		if err != nil {
+			c.Error(apierr.Internal("internal_error", "internal server error").WithCause(err))
+			return
+		}
+
+		prompts, err := repos.Prompts.FindByIDs(ids)
+		if err != nil {
+			c.Error(apierr.FromMongo(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"results": prompts})
+	}
+}
@@ -0,0 +1,59 @@
+// Package prompts implements prompt-history save/search: POST /api/prompts
+// saves a prompt and embeds it, GET /api/prompts/search merges full-text
+// (BM25, via repo.PromptRepo.SearchText) and semantic (cosine-similarity
+// k-NN over prompt_embeddings) hits, and GET /api/prompts/:id/similar finds
+// a single prompt's nearest neighbours.
+package prompts
+
+import (
+	"context"
+	"time"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/db/get"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const vectorIndexName = "prompt_embeddings_vector_index"
+
+// vectorNeighbors runs MongoDB Atlas's $vectorSearch against
+// prompt_embeddings for the k nearest neighbours of vector, excluding
+// excludePromptID (a similar-to-this-one query excludes itself). It returns
+// just the matching PromptIDs in similarity order — callers resolve those
+// back to mongodb.Prompt via repo.PromptRepo.FindByIDs.
+func vectorNeighbors(ctx context.Context, vector []float32, k int, excludePromptID string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	collection := get.GetCollections("prompt_embeddings")
+
+	pipeline := bson.A{
+		bson.M{"$vectorSearch": bson.M{
+			"index":         vectorIndexName,
+			"path":          "vector",
+			"queryVector":   vector,
+			"numCandidates": k * 10,
+			"limit":         k,
+		}},
+		bson.M{"$match": bson.M{"promptId": bson.M{"$ne": excludePromptID}}},
+		bson.M{"$project": bson.M{"promptId": 1}},
+	}
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var hits []struct {
+		PromptID string `bson:"promptId"`
+	}
+	if err := cursor.All(ctx, &hits); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(hits))
+	for _, h := range hits {
+		ids = append(ids, h.PromptID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,14 @@
+// Package rbac defines the canonical set of user roles checked by
+// middleware.RequireRoles. Roles are persisted on mongodb.Users and embedded
+// into issued JWTs under the "roles" claim.
+package rbac
+
+const (
+	Student       = "student"
+	Teacher       = "teacher"
+	Admin         = "admin"
+	ContentAuthor = "content_author"
+)
+
+// DefaultRoles is what a brand new account is granted on sign-up.
+var DefaultRoles = []string{Student}
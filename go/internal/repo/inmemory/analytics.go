@@ -0,0 +1,41 @@
+package inmemoryrepo
+
+import (
+	"sync"
+
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+)
+
+type analyticsRepo struct {
+	mu   sync.RWMutex
+	byID map[string]mongodb.Analytics
+}
+
+func NewAnalyticsRepo() repo.AnalyticsRepo {
+	return &analyticsRepo{byID: make(map[string]mongodb.Analytics)}
+}
+
+func (r *analyticsRepo) Create(analytics mongodb.Analytics) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if analytics.AnalyticsID == "" {
+		analytics.AnalyticsID = newID()
+	}
+	r.byID[analytics.AnalyticsID] = analytics
+	return analytics.AnalyticsID, nil
+}
+
+func (r *analyticsRepo) FindByUser(userID string) ([]mongodb.Analytics, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var records []mongodb.Analytics
+	for _, a := range r.byID {
+		if a.UserID == userID {
+			records = append(records, a)
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,19 @@
+package inmemoryrepo
+
+import "github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+
+// NewContainer wires every repo.* interface to an in-memory, map-backed
+// implementation — handy for unit tests that shouldn't need a live Mongo.
+func NewContainer() *repo.Container {
+	return &repo.Container{
+		Users:     NewUserRepo(),
+		Slides:    NewSlideRepo(),
+		Quizzes:   NewQuizRepo(),
+		Templates: NewTemplateRepo(),
+		Jobs:      NewJobRepo(),
+		Analytics: NewAnalyticsRepo(),
+		WebAuthn:  NewWebAuthnRepo(),
+		Media:     NewMediaRepo(),
+		Prompts:   NewPromptRepo(),
+	}
+}
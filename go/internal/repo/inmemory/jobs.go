@@ -0,0 +1,158 @@
+package inmemoryrepo
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+)
+
+type jobRepo struct {
+	mu          sync.RWMutex
+	byID        map[string]mongodb.Job
+	subscribers map[string][]chan mongodb.Job
+}
+
+func NewJobRepo() repo.JobRepo {
+	return &jobRepo{
+		byID:        make(map[string]mongodb.Job),
+		subscribers: make(map[string][]chan mongodb.Job),
+	}
+}
+
+func (r *jobRepo) Create(job mongodb.Job) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if job.JobID == "" {
+		job.JobID = newID()
+	}
+	r.byID[job.JobID] = job
+	return job.JobID, nil
+}
+
+func (r *jobRepo) FindByID(id string) (*mongodb.Job, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	job, ok := r.byID[id]
+	if !ok {
+		return nil, nil
+	}
+	return &job, nil
+}
+
+func (r *jobRepo) UpdateStatus(id, status, resultURL, errMsg string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.byID[id]
+	if !ok {
+		return nil
+	}
+
+	job.Status = status
+	if resultURL != "" {
+		job.ResultURL = resultURL
+	}
+	if errMsg != "" {
+		job.Error = errMsg
+	}
+	if status == "completed" || status == "failed" {
+		job.CompletedAt = time.Now()
+	}
+	r.byID[id] = job
+	r.publish(job)
+	return nil
+}
+
+// ClaimNext picks the oldest pending job by CreatedAt and flips it to
+// running, mirroring the Mongo findOneAndUpdate claim.
+func (r *jobRepo) ClaimNext(ctx context.Context) (*mongodb.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var pending []mongodb.Job
+	for _, job := range r.byID {
+		if job.Status == "pending" {
+			pending = append(pending, job)
+		}
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(pending, func(i, j int) bool { return pending[i].CreatedAt.Before(pending[j].CreatedAt) })
+
+	job := pending[0]
+	job.Status = "running"
+	job.ClaimedAt = time.Now()
+	r.byID[job.JobID] = job
+	r.publish(job)
+	return &job, nil
+}
+
+func (r *jobRepo) UpdateProgress(id string, pct int, msg string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.byID[id]
+	if !ok {
+		return nil
+	}
+	job.Progress = pct
+	job.ProgressMsg = msg
+	r.byID[id] = job
+	r.publish(job)
+	return nil
+}
+
+func (r *jobRepo) RequeueStale(ctx context.Context, cutoff time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for id, job := range r.byID {
+		if job.Status == "running" && job.ClaimedAt.Before(cutoff) {
+			job.Status = "pending"
+			r.byID[id] = job
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *jobRepo) Subscribe(id string) (<-chan mongodb.Job, func(), error) {
+	r.mu.Lock()
+	ch := make(chan mongodb.Job, 1)
+	r.subscribers[id] = append(r.subscribers[id], ch)
+	r.mu.Unlock()
+
+	unsubscribe := func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		subs := r.subscribers[id]
+		for i, c := range subs {
+			if c == ch {
+				r.subscribers[id] = append(subs[:i], subs[i+1:]...)
+				break
+			}
+		}
+		close(ch)
+	}
+	return ch, unsubscribe, nil
+}
+
+// publish must be called with r.mu held; it fans the job out to every
+// subscriber without blocking on a slow or abandoned receiver.
+func (r *jobRepo) publish(job mongodb.Job) {
+	for _, ch := range r.subscribers[job.JobID] {
+		select {
+		case ch <- job:
+		default:
+		}
+	}
+}
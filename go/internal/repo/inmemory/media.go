@@ -0,0 +1,62 @@
+package inmemoryrepo
+
+import (
+	"sync"
+
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+)
+
+type mediaRepo struct {
+	mu     sync.RWMutex
+	byID   map[string]mongodb.Media
+	byHash map[string]string // hash -> id
+}
+
+func NewMediaRepo() repo.MediaRepo {
+	return &mediaRepo{
+		byID:   make(map[string]mongodb.Media),
+		byHash: make(map[string]string),
+	}
+}
+
+func (r *mediaRepo) FindByHash(hash string) (*mongodb.Media, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.byHash[hash]
+	if !ok {
+		return nil, nil
+	}
+	media := r.byID[id]
+	return &media, nil
+}
+
+func (r *mediaRepo) Create(media mongodb.Media) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if media.MediaID == "" {
+		media.MediaID = newID()
+	}
+	r.byID[media.MediaID] = media
+	r.byHash[media.Hash] = media.MediaID
+	return media.MediaID, nil
+}
+
+func (r *mediaRepo) AddVariant(hash, variantKey, objectKey string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, ok := r.byHash[hash]
+	if !ok {
+		return nil
+	}
+	media := r.byID[id]
+	if media.Variants == nil {
+		media.Variants = make(map[string]string)
+	}
+	media.Variants[variantKey] = objectKey
+	r.byID[id] = media
+	return nil
+}
@@ -0,0 +1,80 @@
+package inmemoryrepo
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+)
+
+type promptRepo struct {
+	mu   sync.RWMutex
+	byID map[string]mongodb.Prompt
+}
+
+func NewPromptRepo() repo.PromptRepo {
+	return &promptRepo{byID: make(map[string]mongodb.Prompt)}
+}
+
+func (r *promptRepo) Create(prompt mongodb.Prompt) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if prompt.PromptID == "" {
+		prompt.PromptID = newID()
+	}
+	r.byID[prompt.PromptID] = prompt
+	return prompt.PromptID, nil
+}
+
+func (r *promptRepo) FindByID(id string) (*mongodb.Prompt, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	prompt, ok := r.byID[id]
+	if !ok {
+		return nil, nil
+	}
+	return &prompt, nil
+}
+
+func (r *promptRepo) FindByIDs(ids []string) ([]mongodb.Prompt, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	prompts := make([]mongodb.Prompt, 0, len(ids))
+	for _, id := range ids {
+		if prompt, ok := r.byID[id]; ok {
+			prompts = append(prompts, prompt)
+		}
+	}
+	return prompts, nil
+}
+
+// SearchText is a plain substring match over the same fields the Mongo text
+// index covers — good enough for tests, not ranked by relevance.
+func (r *promptRepo) SearchText(_ context.Context, query, subject, locale string, limit int) ([]mongodb.Prompt, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	q := strings.ToLower(query)
+	var matches []mongodb.Prompt
+	for _, prompt := range r.byID {
+		if subject != "" && prompt.Subject != subject {
+			continue
+		}
+		if locale != "" && prompt.Locale != locale {
+			continue
+		}
+		haystack := strings.ToLower(prompt.PromptText + " " + prompt.Subject + " " + prompt.GradeLevel + " " + prompt.Context)
+		if strings.Contains(haystack, q) {
+			matches = append(matches, prompt)
+		}
+		if len(matches) >= limit {
+			break
+		}
+	}
+	return matches, nil
+}
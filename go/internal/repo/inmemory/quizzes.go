@@ -0,0 +1,39 @@
+package inmemoryrepo
+
+import (
+	"sync"
+
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+)
+
+type quizRepo struct {
+	mu   sync.RWMutex
+	byID map[string]mongodb.Quiz
+}
+
+func NewQuizRepo() repo.QuizRepo {
+	return &quizRepo{byID: make(map[string]mongodb.Quiz)}
+}
+
+func (r *quizRepo) Create(quiz mongodb.Quiz) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if quiz.QuizID == "" {
+		quiz.QuizID = newID()
+	}
+	r.byID[quiz.QuizID] = quiz
+	return quiz.QuizID, nil
+}
+
+func (r *quizRepo) FindByID(id string) (*mongodb.Quiz, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	quiz, ok := r.byID[id]
+	if !ok {
+		return nil, nil
+	}
+	return &quiz, nil
+}
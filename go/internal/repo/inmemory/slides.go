@@ -0,0 +1,39 @@
+package inmemoryrepo
+
+import (
+	"sync"
+
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+)
+
+type slideRepo struct {
+	mu   sync.RWMutex
+	byID map[string]mongodb.Slide
+}
+
+func NewSlideRepo() repo.SlideRepo {
+	return &slideRepo{byID: make(map[string]mongodb.Slide)}
+}
+
+func (r *slideRepo) Create(slide mongodb.Slide) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if slide.SlideID == "" {
+		slide.SlideID = newID()
+	}
+	r.byID[slide.SlideID] = slide
+	return slide.SlideID, nil
+}
+
+func (r *slideRepo) FindByID(id string) (*mongodb.Slide, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	slide, ok := r.byID[id]
+	if !ok {
+		return nil, nil
+	}
+	return &slide, nil
+}
@@ -0,0 +1,39 @@
+package inmemoryrepo
+
+import (
+	"sync"
+
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+)
+
+type templateRepo struct {
+	mu   sync.RWMutex
+	byID map[string]mongodb.Template
+}
+
+func NewTemplateRepo() repo.TemplateRepo {
+	return &templateRepo{byID: make(map[string]mongodb.Template)}
+}
+
+func (r *templateRepo) Create(template mongodb.Template) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if template.TemplateID == "" {
+		template.TemplateID = newID()
+	}
+	r.byID[template.TemplateID] = template
+	return template.TemplateID, nil
+}
+
+func (r *templateRepo) List() ([]mongodb.Template, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	templates := make([]mongodb.Template, 0, len(r.byID))
+	for _, t := range r.byID {
+		templates = append(templates, t)
+	}
+	return templates, nil
+}
@@ -0,0 +1,108 @@
+package inmemoryrepo
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+)
+
+type userRepo struct {
+	mu      sync.RWMutex
+	byID    map[string]mongodb.Users
+	byEmail map[string]string // email -> id
+}
+
+func NewUserRepo() repo.UserRepo {
+	return &userRepo{
+		byID:    make(map[string]mongodb.Users),
+		byEmail: make(map[string]string),
+	}
+}
+
+func (r *userRepo) FindByEmail(email string) (*mongodb.Users, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.byEmail[email]
+	if !ok {
+		return nil, nil
+	}
+	user := r.byID[id]
+	return &user, nil
+}
+
+func (r *userRepo) Create(user mongodb.Users) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if user.ID == "" {
+		user.ID = newID()
+	}
+	r.byID[user.ID] = user
+	r.byEmail[user.Email] = user.ID
+	return user.ID, nil
+}
+
+func (r *userRepo) FindByID(id string) (*mongodb.Users, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.byID[id]
+	if !ok {
+		return nil, nil
+	}
+	return &user, nil
+}
+
+func (r *userRepo) UpdateLastLogin(email string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, ok := r.byEmail[email]
+	if !ok {
+		return nil
+	}
+	user := r.byID[id]
+	user.LastLogin = time.Now().Format("Monday, 02-Jan-06 15:04:05 MST")
+	r.byID[id] = user
+	return nil
+}
+
+func (r *userRepo) UpdatePasswordHash(id, passwdHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.byID[id]
+	if !ok {
+		return nil
+	}
+	user.PasswdHash = passwdHash
+	r.byID[id] = user
+	return nil
+}
+
+func (r *userRepo) LinkProvider(userID, provider, providerID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.byID[userID]
+	if !ok {
+		return nil
+	}
+	if user.ProviderIDs == nil {
+		user.ProviderIDs = make(map[string]string)
+	}
+	user.ProviderIDs[provider] = providerID
+	r.byID[userID] = user
+	return nil
+}
+
+func newID() string {
+	buf := make([]byte, 12)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
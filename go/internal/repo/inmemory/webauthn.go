@@ -0,0 +1,66 @@
+package inmemoryrepo
+
+import (
+	"sync"
+	"time"
+
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+)
+
+type webauthnRepo struct {
+	mu           sync.RWMutex
+	byCredential map[string]mongodb.Credential
+}
+
+func NewWebAuthnRepo() repo.WebAuthnRepo {
+	return &webauthnRepo{byCredential: make(map[string]mongodb.Credential)}
+}
+
+func (r *webauthnRepo) ListByUser(userID string) ([]mongodb.Credential, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var creds []mongodb.Credential
+	for _, cred := range r.byCredential {
+		if cred.UserID == userID {
+			creds = append(creds, cred)
+		}
+	}
+	return creds, nil
+}
+
+func (r *webauthnRepo) Create(cred mongodb.Credential) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cred.ID == "" {
+		cred.ID = newID()
+	}
+	r.byCredential[cred.CredentialID] = cred
+	return cred.ID, nil
+}
+
+func (r *webauthnRepo) UpdateSignCount(credentialID string, signCount uint32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cred, ok := r.byCredential[credentialID]
+	if !ok {
+		return nil
+	}
+	cred.SignCount = signCount
+	cred.LastUsedAt = time.Now()
+	r.byCredential[credentialID] = cred
+	return nil
+}
+
+func (r *webauthnRepo) Delete(userID, credentialID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cred, ok := r.byCredential[credentialID]; ok && cred.UserID == userID {
+		delete(r.byCredential, credentialID)
+	}
+	return nil
+}
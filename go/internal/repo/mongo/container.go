@@ -0,0 +1,22 @@
+package mongorepo
+
+import (
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// NewContainer wires every repo.* interface to its Mongo-backed
+// implementation against the given database.
+func NewContainer(db *mongo.Database) *repo.Container {
+	return &repo.Container{
+		Users:     NewUserRepo(db),
+		Slides:    NewSlideRepo(db),
+		Quizzes:   NewQuizRepo(db),
+		Templates: NewTemplateRepo(db),
+		Jobs:      NewJobRepo(db),
+		Analytics: NewAnalyticsRepo(db),
+		WebAuthn:  NewWebAuthnRepo(db),
+		Media:     NewMediaRepo(db),
+		Prompts:   NewPromptRepo(db),
+	}
+}
@@ -0,0 +1,21 @@
+package mongorepo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// idFilter builds a {"_id": ObjectID} filter from the hex string returned by
+// a prior Create call. Mongo's _id is stored as an ObjectID, not a string, so
+// filtering on the raw hex (as every repo here used to) never matches a real
+// document — it only looked like it worked because the in-memory test repo
+// keys its map by the same string. ok is false (and the filter nil) if id
+// isn't a valid ObjectID hex string; callers then treat it as "no such
+// document" without querying.
+func idFilter(id string) (bson.M, bool) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, false
+	}
+	return bson.M{"_id": oid}, true
+}
@@ -0,0 +1,39 @@
+package mongorepo
+
+import (
+	"context"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ensureUniqueIndexes mirrors migrations.CreateIndexFeild's reflect-based
+// `unique:"true"` tag discovery, but lives on the Mongo repo itself so each
+// backend owns its own schema setup instead of a central migrations file
+// reaching into every collection.
+func ensureUniqueIndexes(ctx context.Context, collection *mongo.Collection, modal interface{}) error {
+	val := reflect.TypeOf(modal)
+	for i := 0; i < val.NumField(); i++ {
+		field := val.Field(i)
+		if field.Tag.Get("unique") != "true" {
+			continue
+		}
+
+		fieldName := field.Tag.Get("bson")
+		if fieldName == "" {
+			fieldName = field.Name
+		}
+
+		index := mongo.IndexModel{
+			Keys:    bson.D{{Key: fieldName, Value: 1}},
+			Options: options.Index().SetUnique(true),
+		}
+
+		if _, err := collection.Indexes().CreateOne(ctx, index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package mongorepo
+
+import (
+	"context"
+	"time"
+
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type jobRepo struct {
+	collection *mongo.Collection
+}
+
+func NewJobRepo(db *mongo.Database) repo.JobRepo {
+	return &jobRepo{collection: db.Collection("jobs")}
+}
+
+func (r *jobRepo) Create(job mongodb.Job) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.InsertOne(ctx, job)
+	if err != nil {
+		return "", err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		return oid.Hex(), nil
+	}
+	return "", nil
+}
+
+func (r *jobRepo) FindByID(id string) (*mongodb.Job, error) {
+	filter, ok := idFilter(id)
+	if !ok {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var job mongodb.Job
+	err := r.collection.FindOne(ctx, filter).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *jobRepo) UpdateStatus(id, status, resultURL, errMsg string) error {
+	filter, ok := idFilter(id)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	set := bson.M{"status": status}
+	if resultURL != "" {
+		set["resultUrl"] = resultURL
+	}
+	if errMsg != "" {
+		set["error"] = errMsg
+	}
+	if status == "completed" || status == "failed" {
+		set["completedAt"] = time.Now()
+	}
+
+	_, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": set})
+	return err
+}
+
+// ClaimNext uses findOneAndUpdate on {status:"pending"} to atomically hand
+// the oldest pending job to one caller, which is what keeps at-least-once
+// claiming safe across multiple worker processes.
+func (r *jobRepo) ClaimNext(ctx context.Context) (*mongodb.Job, error) {
+	filter := bson.M{"status": "pending"}
+	update := bson.M{"$set": bson.M{"status": "running", "claimedAt": time.Now()}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "createdAt", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var job mongodb.Job
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *jobRepo) UpdateProgress(id string, pct int, msg string) error {
+	filter, ok := idFilter(id)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	set := bson.M{"progress": pct, "progressMsg": msg}
+	_, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": set})
+	return err
+}
+
+// RequeueStale recovers jobs orphaned by a worker crash: anything still
+// "running" with a claimedAt older than cutoff goes back to "pending".
+func (r *jobRepo) RequeueStale(ctx context.Context, cutoff time.Time) (int, error) {
+	filter := bson.M{"status": "running", "claimedAt": bson.M{"$lt": cutoff}}
+	update := bson.M{"$set": bson.M{"status": "pending"}}
+
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.ModifiedCount), nil
+}
+
+// Subscribe tails a Mongo change stream filtered to the given job's _id so
+// the SSE endpoint can push status/progress updates without polling. The
+// collection must live on a replica set for change streams to work.
+func (r *jobRepo) Subscribe(id string) (<-chan mongodb.Job, func(), error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	pipeline := mongo.Pipeline{
+		bson.D{{Key: "$match", Value: bson.D{
+			{Key: "documentKey._id", Value: oid},
+			{Key: "operationType", Value: bson.D{{Key: "$in", Value: bson.A{"update", "replace"}}}},
+		}}},
+	}
+
+	stream, err := r.collection.Watch(ctx, pipeline, options.ChangeStream().SetFullDocument(options.UpdateLookup))
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	out := make(chan mongodb.Job)
+	go func() {
+		defer close(out)
+		for stream.Next(ctx) {
+			var event struct {
+				FullDocument mongodb.Job `bson:"fullDocument"`
+			}
+			if err := stream.Decode(&event); err != nil {
+				continue
+			}
+			select {
+			case out <- event.FullDocument:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	unsubscribe := func() {
+		cancel()
+		_ = stream.Close(context.Background())
+	}
+	return out, unsubscribe, nil
+}
+
+func (r *jobRepo) EnsureIndexes(ctx context.Context) error {
+	return ensureUniqueIndexes(ctx, r.collection, mongodb.Job{})
+}
@@ -0,0 +1,65 @@
+package mongorepo
+
+import (
+	"context"
+	"time"
+
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type mediaRepo struct {
+	collection *mongo.Collection
+}
+
+func NewMediaRepo(db *mongo.Database) repo.MediaRepo {
+	return &mediaRepo{collection: db.Collection("media")}
+}
+
+func (r *mediaRepo) FindByHash(hash string) (*mongodb.Media, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var media mongodb.Media
+	err := r.collection.FindOne(ctx, bson.M{"hash": hash}).Decode(&media)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &media, nil
+}
+
+func (r *mediaRepo) Create(media mongodb.Media) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.InsertOne(ctx, media)
+	if err != nil {
+		return "", err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		return oid.Hex(), nil
+	}
+	return "", nil
+}
+
+func (r *mediaRepo) AddVariant(hash, variantKey, objectKey string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"hash": hash}
+	update := bson.M{"$set": bson.M{"variants." + variantKey: objectKey}}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+func (r *mediaRepo) EnsureIndexes(ctx context.Context) error {
+	return ensureUniqueIndexes(ctx, r.collection, mongodb.Media{})
+}
@@ -0,0 +1,111 @@
+package mongorepo
+
+import (
+	"context"
+	"time"
+
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type promptRepo struct {
+	collection *mongo.Collection
+}
+
+func NewPromptRepo(db *mongo.Database) repo.PromptRepo {
+	return &promptRepo{collection: db.Collection("prompts")}
+}
+
+func (r *promptRepo) Create(prompt mongodb.Prompt) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.InsertOne(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		return oid.Hex(), nil
+	}
+	return "", nil
+}
+
+func (r *promptRepo) FindByID(id string) (*mongodb.Prompt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var prompt mongodb.Prompt
+	err := r.collection.FindOne(ctx, bson.M{"promptId": id}).Decode(&prompt)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &prompt, nil
+}
+
+func (r *promptRepo) FindByIDs(ids []string) ([]mongodb.Prompt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{"promptId": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var prompts []mongodb.Prompt
+	if err := cursor.All(ctx, &prompts); err != nil {
+		return nil, err
+	}
+	return prompts, nil
+}
+
+// SearchText runs a $text query (backed by the compound text index
+// EnsureIndexes creates over promptText/subject/gradeLevel/context) and
+// sorts by its BM25-style textScore, narrowing by subject/locale first when
+// given since those are exact-match filters Atlas Search would otherwise
+// have to apply post-hoc.
+func (r *promptRepo) SearchText(ctx context.Context, query, subject, locale string, limit int) ([]mongodb.Prompt, error) {
+	filter := bson.M{"$text": bson.M{"$search": query}}
+	if subject != "" {
+		filter["subject"] = subject
+	}
+	if locale != "" {
+		filter["locale"] = locale
+	}
+
+	opts := options.Find().
+		SetSort(bson.M{"score": bson.M{"$meta": "textScore"}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var prompts []mongodb.Prompt
+	if err := cursor.All(ctx, &prompts); err != nil {
+		return nil, err
+	}
+	return prompts, nil
+}
+
+func (r *promptRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "promptText", Value: "text"},
+			{Key: "subject", Value: "text"},
+			{Key: "gradeLevel", Value: "text"},
+			{Key: "context", Value: "text"},
+		},
+	})
+	return err
+}
@@ -0,0 +1,58 @@
+package mongorepo
+
+import (
+	"context"
+	"time"
+
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type quizRepo struct {
+	collection *mongo.Collection
+}
+
+func NewQuizRepo(db *mongo.Database) repo.QuizRepo {
+	return &quizRepo{collection: db.Collection("quizzes")}
+}
+
+func (r *quizRepo) Create(quiz mongodb.Quiz) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.InsertOne(ctx, quiz)
+	if err != nil {
+		return "", err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		return oid.Hex(), nil
+	}
+	return "", nil
+}
+
+func (r *quizRepo) FindByID(id string) (*mongodb.Quiz, error) {
+	filter, ok := idFilter(id)
+	if !ok {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var quiz mongodb.Quiz
+	err := r.collection.FindOne(ctx, filter).Decode(&quiz)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &quiz, nil
+}
+
+func (r *quizRepo) EnsureIndexes(ctx context.Context) error {
+	return ensureUniqueIndexes(ctx, r.collection, mongodb.Quiz{})
+}
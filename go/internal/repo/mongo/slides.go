@@ -0,0 +1,58 @@
+package mongorepo
+
+import (
+	"context"
+	"time"
+
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type slideRepo struct {
+	collection *mongo.Collection
+}
+
+func NewSlideRepo(db *mongo.Database) repo.SlideRepo {
+	return &slideRepo{collection: db.Collection("slides")}
+}
+
+func (r *slideRepo) Create(slide mongodb.Slide) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.InsertOne(ctx, slide)
+	if err != nil {
+		return "", err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		return oid.Hex(), nil
+	}
+	return "", nil
+}
+
+func (r *slideRepo) FindByID(id string) (*mongodb.Slide, error) {
+	filter, ok := idFilter(id)
+	if !ok {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var slide mongodb.Slide
+	err := r.collection.FindOne(ctx, filter).Decode(&slide)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &slide, nil
+}
+
+func (r *slideRepo) EnsureIndexes(ctx context.Context) error {
+	return ensureUniqueIndexes(ctx, r.collection, mongodb.Slide{})
+}
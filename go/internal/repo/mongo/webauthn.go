@@ -0,0 +1,75 @@
+package mongorepo
+
+import (
+	"context"
+	"time"
+
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type webauthnRepo struct {
+	collection *mongo.Collection
+}
+
+func NewWebAuthnRepo(db *mongo.Database) repo.WebAuthnRepo {
+	return &webauthnRepo{collection: db.Collection("webauthn_credentials")}
+}
+
+func (r *webauthnRepo) ListByUser(userID string) ([]mongodb.Credential, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, bson.M{"userId": userID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var creds []mongodb.Credential
+	if err := cursor.All(ctx, &creds); err != nil {
+		return nil, err
+	}
+	return creds, nil
+}
+
+func (r *webauthnRepo) Create(cred mongodb.Credential) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := r.collection.InsertOne(ctx, cred)
+	if err != nil {
+		return "", err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		return oid.Hex(), nil
+	}
+	return "", nil
+}
+
+func (r *webauthnRepo) UpdateSignCount(credentialID string, signCount uint32) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"credentialId": credentialID}
+	update := bson.M{"$set": bson.M{"signCount": signCount, "lastUsedAt": time.Now()}}
+
+	_, err := r.collection.UpdateOne(ctx, filter, update)
+	return err
+}
+
+func (r *webauthnRepo) Delete(userID, credentialID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := r.collection.DeleteOne(ctx, bson.M{"userId": userID, "credentialId": credentialID})
+	return err
+}
+
+func (r *webauthnRepo) EnsureIndexes(ctx context.Context) error {
+	return ensureUniqueIndexes(ctx, r.collection, mongodb.Credential{})
+}
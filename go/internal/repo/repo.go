@@ -0,0 +1,109 @@
+// Package repo defines storage-agnostic interfaces for every collection
+// handlers need to touch, so the backend (Mongo today, Postgres or an
+// in-memory map tomorrow) can be swapped without changing handler code.
+package repo
+
+import (
+	"context"
+	"time"
+
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+)
+
+type UserRepo interface {
+	FindByEmail(email string) (*mongodb.Users, error)
+	FindByID(id string) (*mongodb.Users, error)
+	Create(user mongodb.Users) (string, error)
+	UpdateLastLogin(email string) error
+	UpdatePasswordHash(id, passwdHash string) error
+	LinkProvider(userID, provider, providerID string) error
+}
+
+type SlideRepo interface {
+	Create(slide mongodb.Slide) (string, error)
+	FindByID(id string) (*mongodb.Slide, error)
+}
+
+type QuizRepo interface {
+	Create(quiz mongodb.Quiz) (string, error)
+	FindByID(id string) (*mongodb.Quiz, error)
+}
+
+type TemplateRepo interface {
+	Create(template mongodb.Template) (string, error)
+	List() ([]mongodb.Template, error)
+}
+
+type JobRepo interface {
+	Create(job mongodb.Job) (string, error)
+	FindByID(id string) (*mongodb.Job, error)
+	UpdateStatus(id, status, resultURL, errMsg string) error
+
+	// ClaimNext atomically moves the oldest pending job to "running" and
+	// hands it to the caller, or returns (nil, nil) if none are pending.
+	ClaimNext(ctx context.Context) (*mongodb.Job, error)
+	UpdateProgress(id string, pct int, msg string) error
+	// RequeueStale resets jobs stuck in "running" since before cutoff back
+	// to "pending" — used on worker startup to recover from a crash.
+	RequeueStale(ctx context.Context, cutoff time.Time) (int, error)
+	// Subscribe streams status/progress updates for a single job until the
+	// returned unsubscribe func is called. Used by the SSE endpoint.
+	Subscribe(id string) (<-chan mongodb.Job, func(), error)
+}
+
+type AnalyticsRepo interface {
+	Create(analytics mongodb.Analytics) (string, error)
+	FindByUser(userID string) ([]mongodb.Analytics, error)
+}
+
+// WebAuthnRepo stores the passkeys registered against a user, keyed by the
+// authenticator's own CredentialID.
+type WebAuthnRepo interface {
+	ListByUser(userID string) ([]mongodb.Credential, error)
+	Create(cred mongodb.Credential) (string, error)
+	UpdateSignCount(credentialID string, signCount uint32) error
+	Delete(userID, credentialID string) error
+}
+
+// MediaRepo stores the dedupe record for every uploaded image: the
+// content hash that addresses it in internal/storage, its dimensions, and
+// whichever resized variants have been generated so far.
+type MediaRepo interface {
+	FindByHash(hash string) (*mongodb.Media, error)
+	Create(media mongodb.Media) (string, error)
+	AddVariant(hash, variantKey, objectKey string) error
+}
+
+// PromptRepo stores the teacher-authored prompt history full-text search
+// runs against. Semantic (vector) search over the same prompts lives
+// separately in the prompt_embeddings collection (internal/db/get+post),
+// since it's derived data rather than something a handler writes directly.
+type PromptRepo interface {
+	Create(prompt mongodb.Prompt) (string, error)
+	FindByID(id string) (*mongodb.Prompt, error)
+	FindByIDs(ids []string) ([]mongodb.Prompt, error)
+	// SearchText runs a BM25-ranked full-text search over PromptText,
+	// Subject, GradeLevel and Context, optionally narrowed by subject/locale.
+	SearchText(ctx context.Context, query, subject, locale string, limit int) ([]mongodb.Prompt, error)
+}
+
+// Indexer is implemented by repos whose backend needs explicit schema setup
+// (indexes, constraints). Backends that don't need one, like an in-memory
+// map, simply don't implement it.
+type Indexer interface {
+	EnsureIndexes(ctx context.Context) error
+}
+
+// Container bundles every repo a handler might depend on. main.go builds one
+// Container for the chosen backend and hands it to migrations and routes.
+type Container struct {
+	Users     UserRepo
+	Slides    SlideRepo
+	Quizzes   QuizRepo
+	Templates TemplateRepo
+	Jobs      JobRepo
+	Analytics AnalyticsRepo
+	WebAuthn  WebAuthnRepo
+	Media     MediaRepo
+	Prompts   PromptRepo
+}
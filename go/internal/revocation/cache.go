@@ -0,0 +1,110 @@
+// Package revocation tracks revoked access-token jtis so JWTMiddleWare can
+// reject a token right after logout instead of waiting for it to expire
+// naturally. Lookups are backed by the revoked_access_tokens collection
+// (internal/db/get+post), fronted by an in-memory LRU so a revocation check
+// doesn't cost a Mongo round-trip on every request.
+package revocation
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/db/get"
+)
+
+// Default is the package-level Cache JWTMiddleWare consults. Setup must run
+// before the server starts handling requests.
+var Default *Cache
+
+// Setup builds Default. The capacity and TTL are both modest: a revocation
+// becomes visible to every process within one TTL window, and the cache
+// itself never grows past capacity entries.
+func Setup() {
+	Default = NewCache(10000, 30*time.Second)
+}
+
+type entry struct {
+	jti       string
+	revoked   bool
+	expiresAt time.Time
+}
+
+// Cache is a fixed-capacity LRU of jti -> revoked, each entry valid for ttl
+// before it's re-checked against Mongo.
+type Cache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+// NewCache builds a Cache holding at most capacity entries, each trusted for
+// ttl before being re-checked against Mongo.
+func NewCache(capacity int, ttl time.Duration) *Cache {
+	return &Cache{
+		capacity: capacity,
+		ttl:      ttl,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// IsRevoked reports whether jti has been revoked, serving from the local
+// cache when possible and falling back to get.IsAccessTokenRevoked on a
+// miss or expired entry.
+func (c *Cache) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	if revoked, ok := c.get(jti); ok {
+		return revoked, nil
+	}
+
+	revoked, err := get.IsAccessTokenRevoked(jti)
+	if err != nil {
+		return false, err
+	}
+
+	c.set(jti, revoked)
+	return revoked, nil
+}
+
+func (c *Cache) get(jti string) (revoked bool, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[jti]
+	if !ok {
+		return false, false
+	}
+
+	e := el.Value.(*entry)
+	if time.Now().After(e.expiresAt) {
+		c.order.Remove(el)
+		delete(c.items, jti)
+		return false, false
+	}
+
+	c.order.MoveToFront(el)
+	return e.revoked, true
+}
+
+func (c *Cache) set(jti string, revoked bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[jti]; ok {
+		el.Value.(*entry).revoked = revoked
+		el.Value.(*entry).expiresAt = time.Now().Add(c.ttl)
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[jti] = c.order.PushFront(&entry{jti: jti, revoked: revoked, expiresAt: time.Now().Add(c.ttl)})
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*entry).jti)
+	}
+}
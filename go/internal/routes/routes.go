@@ -5,12 +5,22 @@ import (
 
 	auth "github.com/SharveshRamchandani/aieduthon.git/internal/Auth"
 	"github.com/SharveshRamchandani/aieduthon.git/internal/handlers"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/jobs"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/media"
 	"github.com/SharveshRamchandani/aieduthon.git/internal/middleware"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/oidc"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/prompts"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/rbac"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/scope"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/webauthn"
 	"github.com/gin-gonic/gin"
 	"github.com/markbates/goth/gothic"
 )
 
-func Routes(r *gin.Engine) {
+func Routes(r *gin.Engine, repos *repo.Container) {
+
+	jobQueue := jobs.NewMongoQueue(repos.Jobs)
 
 	r.GET("/ping", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -33,10 +43,22 @@ func Routes(r *gin.Engine) {
 		q.Add("provider", provider)
 		c.Request.URL.RawQuery = q.Encode()
 
-		handlers.GoogleCallBackFunction(c)
+		handlers.OAuthCallback(repos)(c)
 	})
 
 	
+	r.POST("/auth/refresh", handlers.RefreshToken(repos))
+	r.POST("/auth/logout", handlers.Logout)
+
+	r.POST("/auth/webauthn/login/begin", webauthn.LoginBegin(repos))
+	r.POST("/auth/webauthn/login/finish", webauthn.LoginFinish(repos))
+
+	r.GET("/auth/oidc/login", oidc.Login)
+	r.GET("/auth/oidc/callback", oidc.Callback(repos))
+	r.GET("/auth/oidc/logout", oidc.Logout)
+
+	r.GET("/media/:hash", media.Get(repos))
+
 	r.GET("/auth/status", func(c *gin.Context) {
 		session, _ := auth.Store.Get(c.Request, "session")
 		if email, ok := session.Values["email"].(string); ok && email != "" {
@@ -53,5 +75,21 @@ func Routes(r *gin.Engine) {
 			claims := c.MustGet("claims").(map[string]any)
 			c.JSON(200, gin.H{"msg": "hello protected", "claims": claims})
 		})
+
+		authorized.POST("/auth/link/:provider", middleware.RequireScopes(scope.AccountManage), handlers.LinkProvider(repos))
+
+		authorized.POST("/auth/webauthn/register/begin", middleware.RequireScopes(scope.AccountManage), webauthn.RegisterBegin(repos))
+		authorized.POST("/auth/webauthn/register/finish", middleware.RequireScopes(scope.AccountManage), webauthn.RegisterFinish(repos))
+		authorized.DELETE("/auth/webauthn/credentials/:id", middleware.RequireScopes(scope.AccountManage), webauthn.RevokeCredential(repos))
+
+		authorized.POST("/media", middleware.RequireRoles(rbac.Teacher, rbac.ContentAuthor, rbac.Admin), media.Upload(repos))
+
+		authorized.POST("/jobs", middleware.RequireRoles(rbac.Teacher, rbac.ContentAuthor, rbac.Admin), jobs.Enqueue(jobQueue))
+		authorized.GET("/jobs/:id", jobs.Status(repos.Jobs))
+		authorized.GET("/jobs/:id/stream", jobs.Stream(repos.Jobs, jobQueue))
+
+		authorized.POST("/prompts", middleware.RequireScopes(scope.PromptsWrite), prompts.Create(repos))
+		authorized.GET("/prompts/search", prompts.Search(repos))
+		authorized.GET("/prompts/:id/similar", prompts.Similar(repos))
 	}
 }
@@ -0,0 +1,59 @@
+// Package scope defines the canonical OAuth-style scopes checked by
+// middleware.RequireScopes. Scopes are persisted on mongodb.Users and
+// embedded into issued JWTs under the "scope" claim as a single
+// space-delimited string, mirroring how OAuth access tokens carry theirs.
+package scope
+
+const (
+	PromptsWrite     = "prompts:write"
+	DiagramsGenerate = "diagrams:generate"
+	TranslationsRead = "translations:read"
+	AccountManage    = "account:manage"
+)
+
+// DefaultScopes is what a brand new account is granted on sign-up.
+// PromptsWrite and AccountManage cover everyday self-service actions (saving
+// a prompt, linking a provider, managing your own passkeys) that every
+// account should start with; revoking one from a specific user (without
+// touching their roles) is how that self-service ability gets suspended.
+var DefaultScopes = []string{TranslationsRead, PromptsWrite, AccountManage}
+
+// Join space-delimits scopes for embedding in a JWT's "scope" claim.
+func Join(scopes []string) string {
+	out := ""
+	for i, s := range scopes {
+		if i > 0 {
+			out += " "
+		}
+		out += s
+	}
+	return out
+}
+
+// Set is a parsed space-delimited scope claim, ready for membership checks.
+type Set map[string]struct{}
+
+// Parse splits a space-delimited "scope" claim into a Set.
+func Parse(claim string) Set {
+	set := make(Set)
+	start := 0
+	for i := 0; i <= len(claim); i++ {
+		if i == len(claim) || claim[i] == ' ' {
+			if i > start {
+				set[claim[start:i]] = struct{}{}
+			}
+			start = i + 1
+		}
+	}
+	return set
+}
+
+// HasAll reports whether every scope in required is present in the set.
+func (s Set) HasAll(required ...string) bool {
+	for _, r := range required {
+		if _, ok := s[r]; !ok {
+			return false
+		}
+	}
+	return true
+}
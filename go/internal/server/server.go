@@ -1,29 +1,68 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
 
 	cors "github.com/SharveshRamchandani/aieduthon.git/internal/Cors"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/jobs"
 	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/middleware"
 	"github.com/SharveshRamchandani/aieduthon.git/internal/modals"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
 	"github.com/SharveshRamchandani/aieduthon.git/internal/routes"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
-func StartServer(cfs *modals.Config){
+// shutdownGrace bounds how long StartServer waits for in-flight HTTP
+// requests and running jobs to drain after SIGTERM before giving up.
+const shutdownGrace = 15 * time.Second
+
+func StartServer(cfs *modals.Config, repos *repo.Container){
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
 	router := gin.New()
 
 	cors.InitCors(router)
+	router.Use(middleware.RequestLogger())
+	router.Use(middleware.ErrorHandler())
 
 	//add router function call
 	logger.Log.Info("Starting server ", zap.String("env->",cfs.Env), zap.String("port->",cfs.Port))
-	routes.Routes(router)
+	routes.Routes(router, repos)
+
+	worker := jobs.NewWorkerPool(repos)
+	worker.Start(context.Background())
 
-	err := router.Run(fmt.Sprintf(":%s",cfs.Port))
-	if err != nil{
-		logger.Log.Error("failed to start the server", zap.String("error", err.Error()))
-		return
+	httpServer := &http.Server{
+		Addr:    fmt.Sprintf(":%s", cfs.Port),
+		Handler: router,
 	}
+
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Log.Error("failed to start the server", zap.String("error", err.Error()))
+		}
+	}()
 	logger.Log.Info("Server Started successfully at",zap.String("env->",cfs.Env), zap.String("port->",cfs.Port))
+
+	<-ctx.Done()
+	logger.Log.Info("shutdown signal received, draining in-flight requests and jobs")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownGrace)
+	defer cancel()
+
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		logger.Log.Error("failed to shut down HTTP server cleanly", zap.Error(err))
+	}
+	if err := worker.Shutdown(shutdownCtx); err != nil {
+		logger.Log.Error("failed to drain in-flight jobs before shutdown", zap.Error(err))
+	}
 }
\ No newline at end of file
@@ -0,0 +1,16 @@
+// Package storage is a content-addressable object store for uploaded media:
+// a Backend abstraction (local disk for dev, MinIO for everywhere else), a
+// SHA-256 dedupe key, and on-the-fly WebP resizing at a few preset widths so
+// internal/media never has to care which backend actually holds the bytes.
+package storage
+
+import "context"
+
+// Backend stores and retrieves opaque objects by key. Both the original
+// upload (media/<hash>) and its generated variants (media/<hash>_<width>.webp)
+// go through the same interface.
+type Backend interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Exists(ctx context.Context, key string) (bool, error)
+}
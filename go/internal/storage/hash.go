@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Hash returns the hex-encoded SHA-256 of data — the dedupe key and
+// object-store path (media/<hash>) for an uploaded original.
+func Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Key returns the storage key for an original upload.
+func Key(hash string) string {
+	return "media/" + hash
+}
+
+// VariantKey returns the storage key (and cache key, passed to
+// repo.MediaRepo.AddVariant) for a resized derivative.
+func VariantKey(hash string, width int) string {
+	return "media/" + hash + "_" + strconv.Itoa(width) + ".webp"
+}
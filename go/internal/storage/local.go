@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// localBackend is the dev fallback: objects live as plain files under
+// baseDir, keyed by their storage key with path separators sanitized away.
+type localBackend struct {
+	baseDir string
+}
+
+// NewLocalBackend returns a Backend rooted at baseDir, creating it if
+// necessary.
+func NewLocalBackend(baseDir string) (Backend, error) {
+	if err := os.MkdirAll(baseDir, 0o755); err != nil {
+		return nil, err
+	}
+	return &localBackend{baseDir: baseDir}, nil
+}
+
+func (b *localBackend) Put(_ context.Context, key string, data []byte, _ string) error {
+	return os.WriteFile(b.path(key), data, 0o644)
+}
+
+func (b *localBackend) Get(_ context.Context, key string) ([]byte, error) {
+	return os.ReadFile(b.path(key))
+}
+
+func (b *localBackend) Exists(_ context.Context, key string) (bool, error) {
+	_, err := os.Stat(b.path(key))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// path maps a storage key onto a filename directly under baseDir, replacing
+// the "/" in keys like "media/<hash>" (see Key/VariantKey) with "_".
+func (b *localBackend) path(key string) string {
+	return filepath.Join(b.baseDir, strings.ReplaceAll(key, "/", "_"))
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// minioBackend stores objects in a single S3-compatible bucket. It's the
+// production Backend; NewLocalBackend is what dev falls back to when
+// MINIO_ENDPOINT isn't set.
+type minioBackend struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewMinioBackend connects to an S3-compatible endpoint and ensures bucket
+// exists, creating it if this is the first deploy.
+func NewMinioBackend(ctx context.Context, endpoint, accessKey, secretKey, bucket string, useSSL bool) (Backend, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := client.BucketExists(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &minioBackend{client: client, bucket: bucket}, nil
+}
+
+func (b *minioBackend) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	_, err := b.client.PutObject(ctx, b.bucket, key, bytes.NewReader(data), int64(len(data)),
+		minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+func (b *minioBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	obj, err := b.client.GetObject(ctx, b.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		return nil, err
+	}
+	// GetObject doesn't error until the first read, so a missing key surfaces
+	// from ReadAll above; Stat is checked as well.
+	if _, statErr := obj.Stat(); statErr != nil {
+		return nil, statErr
+	}
+	return data, nil
+}
+
+func (b *minioBackend) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := b.client.StatObject(ctx, b.bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		var errResp minio.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Code == "NoSuchKey" {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	"go.uber.org/zap"
+)
+
+// Store is the package-level Backend every handler in internal/media uses.
+// Setup must run before routes.Routes wires those handlers up.
+var Store Backend
+
+// Setup builds Store from MINIO_ENDPOINT/MINIO_ACCESS_KEY/MINIO_SECRET_KEY/
+// MINIO_BUCKET/MINIO_USE_SSL. With MINIO_ENDPOINT unset it falls back to a
+// local-disk Backend rooted at MEDIA_DIR (default "./data/media") — good
+// enough for local dev, not for a multi-instance deployment.
+func Setup() error {
+	endpoint := os.Getenv("MINIO_ENDPOINT")
+	if endpoint == "" {
+		dir := os.Getenv("MEDIA_DIR")
+		if dir == "" {
+			dir = "./data/media"
+		}
+
+		local, err := NewLocalBackend(dir)
+		if err != nil {
+			return err
+		}
+		Store = local
+		logger.Log.Debug("storage: Setup: info: using local-disk backend", zap.String("dir", dir))
+		return nil
+	}
+
+	useSSL, _ := strconv.ParseBool(os.Getenv("MINIO_USE_SSL"))
+	bucket := os.Getenv("MINIO_BUCKET")
+	if bucket == "" {
+		bucket = "media"
+	}
+
+	minioStore, err := NewMinioBackend(context.Background(), endpoint,
+		os.Getenv("MINIO_ACCESS_KEY"), os.Getenv("MINIO_SECRET_KEY"), bucket, useSSL)
+	if err != nil {
+		logger.Log.Error("storage: Setup: failed to connect to MinIO", zap.Error(err))
+		return err
+	}
+
+	Store = minioStore
+	logger.Log.Debug("storage: Setup: info: using MinIO backend", zap.String("endpoint", endpoint), zap.String("bucket", bucket))
+	return nil
+}
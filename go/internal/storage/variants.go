@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/chai2010/webp"
+	"golang.org/x/image/draw"
+)
+
+// VariantWidths are the preset sizes generated on first request for a given
+// hash, matching typical slide-embed breakpoints (thumbnail/inline/full).
+var VariantWidths = []int{320, 640, 1280}
+
+// ErrUnsupportedFormat is returned by GenerateVariant for an output format
+// this build can't encode.
+var ErrUnsupportedFormat = errors.New("storage: unsupported variant format")
+
+// Decode reads width/height out of an uploaded original without generating
+// any variant, so internal/media can populate mongodb.Media.Width/Height on
+// upload.
+func Decode(original []byte) (width, height int, err error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(original))
+	if err != nil {
+		return 0, 0, err
+	}
+	return cfg.Width, cfg.Height, nil
+}
+
+// GenerateVariant decodes original and re-encodes it resized to width
+// (preserving aspect ratio) in the given format. Only "webp" is implemented;
+// an AVIF encoder isn't wired up in this build, so callers doing content
+// negotiation should fall back to webp when this returns
+// ErrUnsupportedFormat.
+func GenerateVariant(original []byte, width int, format string) ([]byte, error) {
+	if format != "webp" {
+		return nil, ErrUnsupportedFormat
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(original))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	if bounds.Dx() <= width {
+		width = bounds.Dx()
+	}
+	height := bounds.Dy() * width / bounds.Dx()
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := webp.Encode(&buf, dst, &webp.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
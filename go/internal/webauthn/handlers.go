@@ -0,0 +1,326 @@
+package webauthn
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/SharveshRamchandani/aieduthon.git/internal/apierr"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/handlers"
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/repo"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/scope"
+	"github.com/gin-gonic/gin"
+	"github.com/go-webauthn/webauthn/protocol"
+	gowebauthn "github.com/go-webauthn/webauthn/webauthn"
+	"go.uber.org/zap"
+)
+
+type beginLoginRequest struct {
+	Email string `json:"email"`
+}
+
+type finishRegistrationRequest struct {
+	Nickname string `json:"nickname"`
+}
+
+// RegisterBegin handles POST /auth/webauthn/register/begin (JWT-authenticated):
+// it returns PublicKeyCredentialCreationOptions for the current user and
+// stashes the challenge in the session for RegisterFinish to verify against.
+func RegisterBegin(repos *repo.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := claimedUserID(c)
+		if !ok {
+			c.Error(apierr.Unauthorized("unauthorized", "unauthorized access"))
+			return
+		}
+
+		user, creds, err := loadUserAndCredentials(repos, userID)
+		if err != nil {
+			c.Error(apierr.FromMongo(err))
+			return
+		}
+		if user == nil {
+			c.Error(apierr.NotFound("user_not_found", "user not found"))
+			return
+		}
+
+		options, sessionData, err := WebAuthn.BeginRegistration(&webauthnUser{user: *user, credentials: creds})
+		if err != nil {
+			logger.From(c.Request.Context()).Error("webauthn: RegisterBegin: failed to begin registration", zap.Error(err))
+			c.Error(apierr.Internal("webauthn_begin_failed", "internal server error").WithCause(err))
+			return
+		}
+
+		if err := saveSessionData(c, sessionData); err != nil {
+			c.Error(apierr.Internal("webauthn_session_failed", "internal server error").WithCause(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, options)
+	}
+}
+
+// RegisterFinish handles POST /auth/webauthn/register/finish: it verifies
+// the attestation against the challenge RegisterBegin stashed in the
+// session and persists the new credential.
+func RegisterFinish(repos *repo.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := claimedUserID(c)
+		if !ok {
+			c.Error(apierr.Unauthorized("unauthorized", "unauthorized access"))
+			return
+		}
+
+		user, creds, err := loadUserAndCredentials(repos, userID)
+		if err != nil {
+			c.Error(apierr.FromMongo(err))
+			return
+		}
+		if user == nil {
+			c.Error(apierr.NotFound("user_not_found", "user not found"))
+			return
+		}
+
+		sessionData, err := loadSessionData(c)
+		if err != nil {
+			c.Error(apierr.Unauthorized("webauthn_session_missing", "registration session expired or missing"))
+			return
+		}
+
+		credential, err := WebAuthn.FinishRegistration(&webauthnUser{user: *user, credentials: creds}, *sessionData, c.Request)
+		if err != nil {
+			logger.From(c.Request.Context()).Error("webauthn: RegisterFinish: attestation verification failed", zap.Error(err))
+			c.Error(apierr.BadRequest("webauthn_verification_failed", "could not verify passkey"))
+			return
+		}
+
+		var body finishRegistrationRequest
+		_ = c.ShouldBindJSON(&body)
+
+		transports := make([]string, 0, len(credential.Transport))
+		for _, t := range credential.Transport {
+			transports = append(transports, string(t))
+		}
+
+		record := mongodb.Credential{
+			UserID:       userID,
+			CredentialID: string(credential.ID),
+			PublicKey:    credential.PublicKey,
+			SignCount:    credential.Authenticator.SignCount,
+			Transports:   transports,
+			AAGUID:       credential.Authenticator.AAGUID,
+			CreatedAt:    time.Now(),
+			Nickname:     body.Nickname,
+		}
+
+		if _, err := repos.WebAuthn.Create(record); err != nil {
+			c.Error(apierr.FromMongo(err))
+			return
+		}
+
+		clearSessionData(c)
+		c.JSON(http.StatusOK, gin.H{"message": "passkey registered"})
+	}
+}
+
+// LoginBegin handles POST /auth/webauthn/login/begin. With an email it scopes
+// allowCredentials to that user's stored passkeys; without one it falls back
+// to a discoverable-credential request so the authenticator itself picks
+// which account to assert.
+func LoginBegin(repos *repo.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body beginLoginRequest
+		_ = c.ShouldBindJSON(&body)
+
+		var (
+			options     *protocol.CredentialAssertion
+			sessionData *gowebauthn.SessionData
+			err         error
+		)
+
+		if body.Email == "" {
+			options, sessionData, err = WebAuthn.BeginDiscoverableLogin()
+		} else {
+			user, creds, findErr := loadUserAndCredentialsByEmail(repos, body.Email)
+			if findErr != nil {
+				c.Error(apierr.FromMongo(findErr))
+				return
+			}
+			if user == nil {
+				c.Error(apierr.NotFound("user_not_found", "user not found"))
+				return
+			}
+			options, sessionData, err = WebAuthn.BeginLogin(&webauthnUser{user: *user, credentials: creds})
+		}
+
+		if err != nil {
+			logger.From(c.Request.Context()).Error("webauthn: LoginBegin: failed to begin login", zap.Error(err))
+			c.Error(apierr.Internal("webauthn_begin_failed", "internal server error").WithCause(err))
+			return
+		}
+
+		if err := saveSessionData(c, sessionData); err != nil {
+			c.Error(apierr.Internal("webauthn_session_failed", "internal server error").WithCause(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, options)
+	}
+}
+
+// LoginFinish handles POST /auth/webauthn/login/finish: it verifies the
+// assertion, bumps the credential's sign count, and issues the same
+// JWT+refresh pair as password login.
+func LoginFinish(repos *repo.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sessionData, err := loadSessionData(c)
+		if err != nil {
+			c.Error(apierr.Unauthorized("webauthn_session_missing", "login session expired or missing"))
+			return
+		}
+
+		var (
+			user               *mongodb.Users
+			assertedCredential *gowebauthn.Credential
+		)
+
+		if len(sessionData.UserID) > 0 {
+			user, err = loginWithKnownUser(repos, sessionData, c, &assertedCredential)
+		} else {
+			user, err = loginDiscoverable(repos, sessionData, c, &assertedCredential)
+		}
+
+		if err != nil {
+			logger.From(c.Request.Context()).Error("webauthn: LoginFinish: assertion verification failed", zap.Error(err))
+			c.Error(apierr.Unauthorized("webauthn_verification_failed", "could not verify passkey"))
+			return
+		}
+
+		if err := repos.WebAuthn.UpdateSignCount(string(assertedCredential.ID), assertedCredential.Authenticator.SignCount); err != nil {
+			logger.From(c.Request.Context()).Error("webauthn: LoginFinish: failed to bump sign count", zap.Error(err))
+		}
+
+		clearSessionData(c)
+
+		JwtToken, err := handlers.CreateJWTToken(map[string]any{
+			"name":  user.UserName,
+			"ID":    user.ID,
+			"email": user.Email,
+			"roles": user.Roles,
+			"scope": scope.Join(user.Scopes),
+		})
+		if err != nil {
+			c.Error(apierr.Internal("token_creation_failed", "internal server error").WithCause(err))
+			return
+		}
+
+		refreshToken, err := handlers.IssueRefreshToken(c.Request.Context(), user.ID, c.Request.UserAgent(), c.ClientIP())
+		if err != nil {
+			c.Error(apierr.Internal("token_creation_failed", "internal server error").WithCause(err))
+			return
+		}
+
+		if err := repos.Users.UpdateLastLogin(user.Email); err != nil {
+			logger.From(c.Request.Context()).Error("webauthn: LoginFinish: failed to update login time", zap.Error(err), zap.String("email", user.Email))
+		}
+
+		JwtExp := time.Now().Add(handlers.AccessTokenTTL).Unix()
+		c.SetCookie("jwt", JwtToken, int(JwtExp), "/", "localhost", false, true)
+		c.SetCookie("refresh_token", refreshToken, int(handlers.RefreshTokenTTL.Seconds()), "/", "localhost", false, true)
+
+		c.JSON(http.StatusOK, gin.H{"message": "logged in with passkey"})
+	}
+}
+
+func loginWithKnownUser(repos *repo.Container, sessionData *gowebauthn.SessionData, c *gin.Context, out **gowebauthn.Credential) (*mongodb.Users, error) {
+	user, creds, err := loadUserAndCredentials(repos, string(sessionData.UserID))
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apierr.NotFound("user_not_found", "user not found")
+	}
+
+	cred, err := WebAuthn.FinishLogin(&webauthnUser{user: *user, credentials: creds}, *sessionData, c.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	*out = cred
+	return user, nil
+}
+
+func loginDiscoverable(repos *repo.Container, sessionData *gowebauthn.SessionData, c *gin.Context, out **gowebauthn.Credential) (*mongodb.Users, error) {
+	var user *mongodb.Users
+
+	cred, err := WebAuthn.FinishDiscoverableLogin(func(rawID, userHandle []byte) (gowebauthn.User, error) {
+		u, creds, err := loadUserAndCredentials(repos, string(userHandle))
+		if err != nil {
+			return nil, err
+		}
+		if u == nil {
+			return nil, apierr.NotFound("user_not_found", "user not found")
+		}
+		user = u
+		return &webauthnUser{user: *u, credentials: creds}, nil
+	}, *sessionData, c.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	*out = cred
+	return user, nil
+}
+
+// RevokeCredential handles DELETE /auth/webauthn/credentials/:id, letting an
+// authenticated user remove one of their own passkeys.
+func RevokeCredential(repos *repo.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := claimedUserID(c)
+		if !ok {
+			c.Error(apierr.Unauthorized("unauthorized", "unauthorized access"))
+			return
+		}
+
+		credentialID := c.Param("id")
+		if err := repos.WebAuthn.Delete(userID, credentialID); err != nil {
+			c.Error(apierr.FromMongo(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "passkey revoked"})
+	}
+}
+
+func claimedUserID(c *gin.Context) (string, bool) {
+	claims := c.MustGet("claims").(map[string]any)
+	userID, _ := claims["ID"].(string)
+	return userID, userID != ""
+}
+
+func loadUserAndCredentials(repos *repo.Container, userID string) (*mongodb.Users, []mongodb.Credential, error) {
+	user, err := repos.Users.FindByID(userID)
+	if err != nil || user == nil {
+		return user, nil, err
+	}
+
+	creds, err := repos.WebAuthn.ListByUser(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, creds, nil
+}
+
+func loadUserAndCredentialsByEmail(repos *repo.Container, email string) (*mongodb.Users, []mongodb.Credential, error) {
+	user, err := repos.Users.FindByEmail(email)
+	if err != nil || user == nil {
+		return user, nil, err
+	}
+
+	creds, err := repos.WebAuthn.ListByUser(user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, creds, nil
+}
@@ -0,0 +1,47 @@
+package webauthn
+
+import (
+	"encoding/json"
+	"fmt"
+
+	auth "github.com/SharveshRamchandani/aieduthon.git/internal/Auth"
+	"github.com/gin-gonic/gin"
+	gowebauthn "github.com/go-webauthn/webauthn/webauthn"
+)
+
+// sessionKey is where the in-progress challenge lives in the gorilla
+// session between a /begin call and its matching /finish call.
+const sessionKey = "webauthn_session"
+
+func saveSessionData(c *gin.Context, sessionData *gowebauthn.SessionData) error {
+	session, _ := auth.Store.Get(c.Request, "session")
+
+	raw, err := json.Marshal(sessionData)
+	if err != nil {
+		return err
+	}
+
+	session.Values[sessionKey] = string(raw)
+	return session.Save(c.Request, c.Writer)
+}
+
+func loadSessionData(c *gin.Context) (*gowebauthn.SessionData, error) {
+	session, _ := auth.Store.Get(c.Request, "session")
+
+	raw, ok := session.Values[sessionKey].(string)
+	if !ok || raw == "" {
+		return nil, fmt.Errorf("webauthn: no challenge in session")
+	}
+
+	var sessionData gowebauthn.SessionData
+	if err := json.Unmarshal([]byte(raw), &sessionData); err != nil {
+		return nil, err
+	}
+	return &sessionData, nil
+}
+
+func clearSessionData(c *gin.Context) {
+	session, _ := auth.Store.Get(c.Request, "session")
+	delete(session.Values, sessionKey)
+	_ = session.Save(c.Request, c.Writer)
+}
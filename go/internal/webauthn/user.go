@@ -0,0 +1,39 @@
+package webauthn
+
+import (
+	mongodb "github.com/SharveshRamchandani/aieduthon.git/internal/modals/mongoDB"
+	"github.com/go-webauthn/webauthn/protocol"
+	gowebauthn "github.com/go-webauthn/webauthn/webauthn"
+)
+
+// webauthnUser adapts a mongodb.Users plus its stored credentials to the
+// gowebauthn.User interface the library needs during registration and login.
+type webauthnUser struct {
+	user        mongodb.Users
+	credentials []mongodb.Credential
+}
+
+func (u *webauthnUser) WebAuthnID() []byte          { return []byte(u.user.ID) }
+func (u *webauthnUser) WebAuthnName() string        { return u.user.Email }
+func (u *webauthnUser) WebAuthnDisplayName() string { return u.user.UserName }
+
+func (u *webauthnUser) WebAuthnCredentials() []gowebauthn.Credential {
+	out := make([]gowebauthn.Credential, 0, len(u.credentials))
+	for _, c := range u.credentials {
+		transports := make([]protocol.AuthenticatorTransport, 0, len(c.Transports))
+		for _, t := range c.Transports {
+			transports = append(transports, protocol.AuthenticatorTransport(t))
+		}
+
+		out = append(out, gowebauthn.Credential{
+			ID:        []byte(c.CredentialID),
+			PublicKey: c.PublicKey,
+			Transport: transports,
+			Authenticator: gowebauthn.Authenticator{
+				SignCount: c.SignCount,
+				AAGUID:    c.AAGUID,
+			},
+		})
+	}
+	return out
+}
@@ -0,0 +1,53 @@
+// Package webauthn wires github.com/go-webauthn/webauthn into the rest of
+// the app: a package-level WebAuthn instance built from env, a User adapter
+// over mongodb.Users/Credential, and the gin handlers for the four
+// register/login endpoints plus passkey revocation.
+package webauthn
+
+import (
+	"os"
+
+	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
+	gowebauthn "github.com/go-webauthn/webauthn/webauthn"
+	"go.uber.org/zap"
+)
+
+// WebAuthn is the package-level go-webauthn instance built by Setup. Every
+// handler in this package relies on it being initialized before routes.Routes
+// wires them up.
+var WebAuthn *gowebauthn.WebAuthn
+
+// Setup builds the package-level WebAuthn instance from WEBAUTHN_RPID,
+// WEBAUTHN_ORIGIN and WEBAUTHN_DISPLAY_NAME. Unset values fall back to
+// localhost-friendly defaults so local dev works out of the box; production
+// deployments must set all three explicitly.
+func Setup() error {
+	rpid := os.Getenv("WEBAUTHN_RPID")
+	if rpid == "" {
+		rpid = "localhost"
+	}
+
+	origin := os.Getenv("WEBAUTHN_ORIGIN")
+	if origin == "" {
+		origin = "http://localhost:3000"
+	}
+
+	displayName := os.Getenv("WEBAUTHN_DISPLAY_NAME")
+	if displayName == "" {
+		displayName = "aieduthon"
+	}
+
+	w, err := gowebauthn.New(&gowebauthn.Config{
+		RPDisplayName: displayName,
+		RPID:          rpid,
+		RPOrigins:     []string{origin},
+	})
+	if err != nil {
+		logger.Log.Error("webauthn: Setup: failed to build WebAuthn instance", zap.Error(err))
+		return err
+	}
+
+	WebAuthn = w
+	logger.Log.Debug("webauthn: Setup: info: instance ready", zap.String("rpid", rpid), zap.String("origin", origin))
+	return nil
+}
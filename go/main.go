@@ -3,9 +3,16 @@ package main
 import (
 	"github.com/SharveshRamchandani/aieduthon.git/internal/config"
 	"github.com/SharveshRamchandani/aieduthon.git/internal/db"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/embed"
 	logger "github.com/SharveshRamchandani/aieduthon.git/internal/log"
 	"github.com/SharveshRamchandani/aieduthon.git/internal/migrations"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/oidc"
+	mongorepo "github.com/SharveshRamchandani/aieduthon.git/internal/repo/mongo"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/revocation"
 	"github.com/SharveshRamchandani/aieduthon.git/internal/server"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/storage"
+	"github.com/SharveshRamchandani/aieduthon.git/internal/webauthn"
+	"go.uber.org/zap"
 )
 
 func main() {
@@ -21,8 +28,27 @@ func main() {
 	//Establishing connection with DB
 	db.ConnectDatabase()
 
-	migrations.RunMigrations()
+	//Repos let handlers talk to storage through an interface instead of
+	//reaching into db.MongoDataBase directly, so the backend can be swapped.
+	repos := mongorepo.NewContainer(db.MongoDataBase)
 
-	//Starting server 
-	server.StartServer(cfs)
+	migrations.RunMigrations(repos)
+
+	if err := webauthn.Setup(); err != nil {
+		logger.Log.Error("failed to set up WebAuthn", zap.Error(err))
+	}
+
+	if err := oidc.Setup(); err != nil {
+		logger.Log.Error("failed to set up OIDC provider", zap.Error(err))
+	}
+
+	if err := storage.Setup(); err != nil {
+		logger.Log.Error("failed to set up media storage backend", zap.Error(err))
+	}
+
+	embed.Setup()
+	revocation.Setup()
+
+	//Starting server
+	server.StartServer(cfs, repos)
 }